@@ -0,0 +1,165 @@
+// Package bayes provides conjugate priors for Bayesian inference, as in
+// the book's coin-flipping examples.  Each prior is updated with observed
+// data into a posterior of the same family, which can report credible
+// intervals and draw from the posterior predictive distribution.  Random
+// draws come from a caller-supplied *rand.Rand so results are reproducible.
+package bayes
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/dcooper46/go-ds-from-scratch/distributions"
+)
+
+// Conjugate is a prior or posterior belief about the parameter
+// of a model for the data
+type Conjugate interface {
+	// Distribution returns the belief about the parameter
+	Distribution() distributions.Continuous
+	// Predict draws a new observation from the posterior predictive
+	// distribution, drawing the parameter first
+	Predict(r *rand.Rand) float64
+}
+
+// BetaBernoulli is a Beta(Alpha, Beta) belief about the success
+// probability of Bernoulli trials, such as a coin or a conversion rate.
+// Beta(1, 1) is the uniform prior.
+type BetaBernoulli struct {
+	Alpha float64
+	Beta  float64
+}
+
+// Update returns the posterior after observing successes and failures
+func (b BetaBernoulli) Update(successes, failures int) (BetaBernoulli, error) {
+	if !(b.Alpha > 0.0 && b.Beta > 0.0) {
+		return b, fmt.Errorf("prior parameters must be positive, got %f and %f", b.Alpha, b.Beta)
+	}
+	if successes < 0 || failures < 0 {
+		return b, fmt.Errorf("counts must be non-negative, got %d and %d", successes, failures)
+	}
+	return BetaBernoulli{Alpha: b.Alpha + float64(successes), Beta: b.Beta + float64(failures)}, nil
+}
+
+// Distribution returns the belief about the success probability
+func (b BetaBernoulli) Distribution() distributions.Continuous {
+	return distributions.Beta{Alpha: b.Alpha, Beta: b.Beta}
+}
+
+// Predict draws the outcome of a new trial, 1 for success and 0 for failure
+func (b BetaBernoulli) Predict(r *rand.Rand) float64 {
+	p := b.Distribution().Rand(r)
+	return float64(distributions.Bernoulli{P: p}.Rand(r))
+}
+
+// GammaPoisson is a Gamma(Shape, Rate) belief about the rate
+// of Poisson counts, such as visits per hour
+type GammaPoisson struct {
+	Shape float64
+	Rate  float64
+}
+
+// Update returns the posterior after observing counts
+func (g GammaPoisson) Update(counts []int) (GammaPoisson, error) {
+	if !(g.Shape > 0.0 && g.Rate > 0.0) {
+		return g, fmt.Errorf("prior parameters must be positive, got %f and %f", g.Shape, g.Rate)
+	}
+	post := g
+	for _, k := range counts {
+		if k < 0 {
+			return g, fmt.Errorf("counts must be non-negative, got %d", k)
+		}
+		post.Shape += float64(k)
+	}
+	post.Rate += float64(len(counts))
+	return post, nil
+}
+
+// Distribution returns the belief about the rate
+func (g GammaPoisson) Distribution() distributions.Continuous {
+	return distributions.Gamma{Shape: g.Shape, Rate: g.Rate}
+}
+
+// Predict draws a new count
+func (g GammaPoisson) Predict(r *rand.Rand) float64 {
+	lambda := g.Distribution().Rand(r)
+	return float64(distributions.Poisson{Lambda: lambda}.Rand(r))
+}
+
+// NormalNormal is a Normal(Mu, Tau) belief about the mean of
+// normal data whose standard deviation Sigma is known
+type NormalNormal struct {
+	Mu    float64
+	Tau   float64
+	Sigma float64
+}
+
+// Update returns the posterior after observing x.  The posterior
+// precision is the sum of the prior precision and the data's, and its
+// mean the precision-weighted average of the prior mean and the data.
+func (n NormalNormal) Update(x []float64) (NormalNormal, error) {
+	if !(n.Tau > 0.0 && n.Sigma > 0.0) {
+		return n, fmt.Errorf("standard deviations must be positive, got %f and %f", n.Tau, n.Sigma)
+	}
+	var sum float64
+	for _, v := range x {
+		sum += v
+	}
+	priorPrec := 1.0 / (n.Tau * n.Tau)
+	dataPrec := float64(len(x)) / (n.Sigma * n.Sigma)
+	prec := priorPrec + dataPrec
+	return NormalNormal{
+		Mu:    (priorPrec*n.Mu + sum/(n.Sigma*n.Sigma)) / prec,
+		Tau:   math.Sqrt(1.0 / prec),
+		Sigma: n.Sigma,
+	}, nil
+}
+
+// Distribution returns the belief about the mean
+func (n NormalNormal) Distribution() distributions.Continuous {
+	return distributions.Normal{Mu: n.Mu, Sigma: n.Tau}
+}
+
+// Predict draws a new observation
+func (n NormalNormal) Predict(r *rand.Rand) float64 {
+	mu := n.Distribution().Rand(r)
+	return distributions.Normal{Mu: mu, Sigma: n.Sigma}.Rand(r)
+}
+
+// CredibleInterval returns the equal-tailed interval holding the
+// parameter with the given probability under the belief c
+func CredibleInterval(c Conjugate, level float64) (lower, upper float64, err error) {
+	if !(level > 0.0 && level < 1.0) {
+		return 0.0, 0.0, fmt.Errorf("credible level must be in (0, 1), got %f", level)
+	}
+	d := c.Distribution()
+	return d.Quantile((1.0 - level) / 2.0), d.Quantile((1.0 + level) / 2.0), nil
+}
+
+// PredictiveSample draws n new observations from the posterior predictive
+// distribution, so uncertainty about the parameter is carried through
+func PredictiveSample(c Conjugate, n int, r *rand.Rand) []float64 {
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = c.Predict(r)
+	}
+	return samples
+}
+
+// ProbabilityOfSuperiority estimates the probability that the parameter
+// of variant b exceeds that of variant a, from the given number of
+// independent draws of each posterior
+func ProbabilityOfSuperiority(a, b Conjugate, draws int, r *rand.Rand) (float64, error) {
+	if draws < 1 {
+		return 0.0, fmt.Errorf("need at least 1 draws, got %d", draws)
+	}
+	da, db := a.Distribution(), b.Distribution()
+	var wins int
+	for i := 0; i < draws; i++ {
+		if db.Rand(r) > da.Rand(r) {
+			wins++
+		}
+	}
+	return float64(wins) / float64(draws), nil
+}
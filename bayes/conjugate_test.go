@@ -0,0 +1,122 @@
+package bayes
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+func round3(x float64) float64 {
+	return math.Round(x*1000) / 1000
+}
+
+func TestBetaBernoulli(t *testing.T) {
+	post, err := BetaBernoulli{Alpha: 1.0, Beta: 1.0}.Update(7, 3)
+	if err != nil {
+		t.Fatalf("error calling Update(7, 3): %s", err)
+	}
+	if post.Alpha != 8.0 || post.Beta != 4.0 {
+		t.Fatalf("BetaBernoulli{1, 1}.Update(7, 3) = %+v; want {8 4}", post)
+	}
+	if actual := round3(post.Distribution().Mean()); actual != 0.667 {
+		t.Fatalf("BetaBernoulli{8, 4} mean = %f; want 0.667", actual)
+	}
+	lower, upper, err := CredibleInterval(post, 0.95)
+	if err != nil {
+		t.Fatalf("error calling CredibleInterval(post, 0.95): %s", err)
+	}
+	if round3(lower) != 0.39 || round3(upper) != 0.891 {
+		t.Fatalf("CredibleInterval(BetaBernoulli{8, 4}, 0.95) = (%f, %f); want (0.39, 0.891)", lower, upper)
+	}
+	if _, err := (BetaBernoulli{Alpha: 1.0, Beta: 1.0}).Update(-1, 3); err == nil {
+		t.Fatalf("Update(-1, 3) should raise error: counts must be non-negative")
+	}
+	if _, err := (BetaBernoulli{Alpha: 0.0, Beta: 1.0}).Update(1, 3); err == nil {
+		t.Fatalf("BetaBernoulli{0, 1}.Update should raise error: prior parameters must be positive")
+	}
+	if _, _, err := CredibleInterval(post, 1.0); err == nil {
+		t.Fatalf("CredibleInterval(post, 1) should raise error: credible level must be in (0, 1)")
+	}
+}
+
+func TestGammaPoisson(t *testing.T) {
+	post, err := GammaPoisson{Shape: 2.0, Rate: 1.0}.Update([]int{3, 4, 2, 5})
+	if err != nil {
+		t.Fatalf("error calling Update([3 4 2 5]): %s", err)
+	}
+	if post.Shape != 16.0 || post.Rate != 5.0 {
+		t.Fatalf("GammaPoisson{2, 1}.Update([3 4 2 5]) = %+v; want {16 5}", post)
+	}
+	if actual := post.Distribution().Mean(); actual != 3.2 {
+		t.Fatalf("GammaPoisson{16, 5} mean = %f; want 3.2", actual)
+	}
+	if _, err := (GammaPoisson{Shape: 2.0, Rate: 1.0}).Update([]int{3, -1}); err == nil {
+		t.Fatalf("Update([3 -1]) should raise error: counts must be non-negative")
+	}
+}
+
+func TestNormalNormal(t *testing.T) {
+	post, err := NormalNormal{Mu: 0.0, Tau: 10.0, Sigma: 2.0}.Update([]float64{4.8, 5.6, 5.1, 4.3, 6.0})
+	if err != nil {
+		t.Fatalf("error calling Update(x): %s", err)
+	}
+	if round3(post.Mu) != 5.119 || round3(post.Tau) != 0.891 || post.Sigma != 2.0 {
+		t.Fatalf("NormalNormal{0, 10, 2}.Update(x) = %+v; want {5.119 0.891 2}", post)
+	}
+	if _, err := (NormalNormal{Mu: 0.0, Tau: 10.0}).Update(nil); err == nil {
+		t.Fatalf("NormalNormal{0, 10, 0}.Update should raise error: standard deviations must be positive")
+	}
+}
+
+func TestPredictiveSample(t *testing.T) {
+	beliefs := map[string]Conjugate{
+		"BetaBernoulli": BetaBernoulli{Alpha: 8.0, Beta: 4.0},
+		"GammaPoisson":  GammaPoisson{Shape: 16.0, Rate: 5.0},
+		"NormalNormal":  NormalNormal{Mu: 5.0, Tau: 1.0, Sigma: 2.0},
+	}
+	// predictive means match the posterior means, and the normal
+	// predictive variance adds the data's variance to the belief's
+	for name, c := range beliefs {
+		samples := PredictiveSample(c, 20000, rand.New(rand.NewSource(42)))
+		mu, _ := utils.Mean(samples)
+		if want := c.Distribution().Mean(); math.Abs(mu-want) > 0.05*want {
+			t.Fatalf("%s predictive mean = %f; want %f", name, mu, want)
+		}
+	}
+	samples := PredictiveSample(beliefs["NormalNormal"], 20000, rand.New(rand.NewSource(42)))
+	if v, _ := utils.Variance(samples); math.Abs(v-5.0) > 0.25 {
+		t.Fatalf("NormalNormal predictive variance = %f; want 5", v)
+	}
+
+	// the same seed gives the same draws
+	a := PredictiveSample(beliefs["GammaPoisson"], 10, rand.New(rand.NewSource(7)))
+	b := PredictiveSample(beliefs["GammaPoisson"], 10, rand.New(rand.NewSource(7)))
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("PredictiveSample with seed 7 = %v, then %v; want equal", a, b)
+		}
+	}
+}
+
+func TestProbabilityOfSuperiority(t *testing.T) {
+	prior := BetaBernoulli{Alpha: 1.0, Beta: 1.0}
+	a, _ := prior.Update(20, 80)
+	b, _ := prior.Update(30, 70)
+	// the exact value is 0.9476
+	p, err := ProbabilityOfSuperiority(a, b, 20000, rand.New(rand.NewSource(42)))
+	if err != nil {
+		t.Fatalf("error calling ProbabilityOfSuperiority(a, b): %s", err)
+	}
+	if math.Abs(p-0.9476) > 0.01 {
+		t.Fatalf("ProbabilityOfSuperiority(a, b) = %f; want 0.9476", p)
+	}
+	q, _ := ProbabilityOfSuperiority(b, a, 20000, rand.New(rand.NewSource(42)))
+	if math.Abs(p+q-1.0) > 0.01 {
+		t.Fatalf("ProbabilityOfSuperiority(b, a) = %f; want 1 - %f", q, p)
+	}
+	if _, err := ProbabilityOfSuperiority(a, b, 0, rand.New(rand.NewSource(42))); err == nil {
+		t.Fatalf("ProbabilityOfSuperiority with 0 draws should raise error: need at least 1 draws")
+	}
+}
@@ -0,0 +1,271 @@
+// Package bootstrap estimates the sampling distribution of a statistic
+// by recomputing it on datasets resampled with replacement, as in the
+// book's regression chapter.  Resampling is seeded so results are
+// reproducible, and replicates are shared between a pool of goroutines
+// without changing the results.
+package bootstrap
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dcooper46/go-ds-from-scratch/distributions"
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+// Statistic computes one or more values from a sample of the data
+type Statistic[D any] func(sample []D) ([]float64, error)
+
+// Scalar adapts a function returning a single value, such as
+// utils.Median[float64], to a Statistic
+func Scalar[D any](f func([]D) (float64, error)) Statistic[D] {
+	return func(sample []D) ([]float64, error) {
+		v, err := f(sample)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{v}, nil
+	}
+}
+
+// Options controls a bootstrap run
+type Options struct {
+	// Replicates is the number of resampled datasets, 1000 if 0
+	Replicates int
+	// Seed seeds the random resampling
+	Seed int64
+	// Workers is the number of goroutines, all processors if 0
+	Workers int
+}
+
+// Interval is a confidence interval for one value of a statistic
+type Interval struct {
+	Lower float64
+	Upper float64
+}
+
+// Result holds the statistic on the original data
+// and on each resampled dataset
+type Result struct {
+	// Estimate is the statistic computed on the original data
+	Estimate []float64
+	// Replicates[b] is the statistic computed on resample b
+	Replicates [][]float64
+
+	// jackknife computes the leave-one-out statistics for BCa
+	jackknife func() ([][]float64, error)
+}
+
+// parallelMap computes f(0), ..., f(n-1) between the given number of
+// goroutines.  When several calls fail it returns the error of the lowest
+// index, so the error does not depend on which goroutine finishes first.
+func parallelMap(n, workers int, f func(i int) ([]float64, error)) ([][]float64, error) {
+	out := make([][]float64, n)
+	errs := make([]error, n)
+	// indices above the lowest failure so far are skipped, which
+	// never skips the failure that is finally returned
+	var failed atomic.Int64
+	failed.Store(int64(n))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if int64(i) > failed.Load() {
+					continue
+				}
+				out[i], errs[i] = f(i)
+				for errs[i] != nil {
+					lowest := failed.Load()
+					if int64(i) >= lowest || failed.CompareAndSwap(lowest, int64(i)) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// checkWidth returns an error if a statistic changed length between samples
+func checkWidth(values []float64, want int) error {
+	if len(values) != want {
+		return fmt.Errorf("statistic returned %d values, want %d", len(values), want)
+	}
+	return nil
+}
+
+// Run computes the statistic on the data and on resamples of it.
+// Each replicate draws from its own generator, seeded in order from
+// opts.Seed, so results do not depend on the number of workers.
+func Run[D any](data []D, stat Statistic[D], opts Options) (*Result, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("need at least 1 values, got 0")
+	}
+	replicates := opts.Replicates
+	if replicates == 0 {
+		replicates = 1000
+	}
+	if replicates < 2 {
+		return nil, fmt.Errorf("need at least 2 replicates, got %d", replicates)
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = utils.DefaultWorkers()
+	}
+
+	estimate, err := stat(data)
+	if err != nil {
+		return nil, err
+	}
+	seeds := make([]int64, replicates)
+	master := rand.New(rand.NewSource(opts.Seed))
+	for b := range seeds {
+		seeds[b] = master.Int63()
+	}
+	reps, err := parallelMap(replicates, workers, func(b int) ([]float64, error) {
+		r := rand.New(rand.NewSource(seeds[b]))
+		sample := make([]D, len(data))
+		for i := range sample {
+			sample[i] = data[r.Intn(len(data))]
+		}
+		values, err := stat(sample)
+		if err != nil {
+			return nil, err
+		}
+		return values, checkWidth(values, len(estimate))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jackknife := func() ([][]float64, error) {
+		return parallelMap(len(data), workers, func(i int) ([]float64, error) {
+			sample := make([]D, 0, len(data)-1)
+			sample = append(sample, data[:i]...)
+			sample = append(sample, data[i+1:]...)
+			values, err := stat(sample)
+			if err != nil {
+				return nil, err
+			}
+			return values, checkWidth(values, len(estimate))
+		})
+	}
+	return &Result{
+		Estimate:   estimate,
+		Replicates: reps,
+		jackknife:  jackknife,
+	}, nil
+}
+
+// column returns the replicates of the j-th value of the statistic
+func (r *Result) column(j int) []float64 {
+	col := make([]float64, len(r.Replicates))
+	for b, rep := range r.Replicates {
+		col[b] = rep[j]
+	}
+	return col
+}
+
+// StdErr returns the bootstrap standard error of each value,
+// the standard deviation of its replicates
+func (r *Result) StdErr() ([]float64, error) {
+	se := make([]float64, len(r.Estimate))
+	for j := range se {
+		std, err := utils.StandardDeviation(r.column(j))
+		if err != nil {
+			return nil, err
+		}
+		se[j] = std
+	}
+	return se, nil
+}
+
+// Percentile returns the percentile intervals at the given confidence
+// level, the quantiles of the replicates at (1-level)/2 and (1+level)/2
+func (r *Result) Percentile(level float64) ([]Interval, error) {
+	if !(level > 0.0 && level < 1.0) {
+		return nil, fmt.Errorf("confidence level must be in (0, 1), got %f", level)
+	}
+	alpha := (1.0 - level) / 2.0
+	intervals := make([]Interval, len(r.Estimate))
+	for j := range intervals {
+		q, err := utils.Quantiles(r.column(j), []float64{alpha, 1.0 - alpha}, utils.Linear)
+		if err != nil {
+			return nil, err
+		}
+		intervals[j] = Interval{Lower: q[0], Upper: q[1]}
+	}
+	return intervals, nil
+}
+
+// BCa returns the bias-corrected and accelerated intervals at the given
+// confidence level.  The bias correction comes from the share of replicates
+// below the estimate and the acceleration from a jackknife, which computes
+// the statistic once more for every observation.
+func (r *Result) BCa(level float64) ([]Interval, error) {
+	if !(level > 0.0 && level < 1.0) {
+		return nil, fmt.Errorf("confidence level must be in (0, 1), got %f", level)
+	}
+	jack, err := r.jackknife()
+	if err != nil {
+		return nil, err
+	}
+	norm := distributions.StandardNormal
+	zLo, zHi := norm.Quantile((1.0-level)/2.0), norm.Quantile((1.0+level)/2.0)
+	intervals := make([]Interval, len(r.Estimate))
+	for j, theta := range r.Estimate {
+		reps := r.column(j)
+		var below int
+		for _, v := range reps {
+			if v < theta {
+				below++
+			}
+		}
+		if below == 0 || below == len(reps) {
+			return nil, fmt.Errorf("bias correction is undefined: every replicate of value %d is on one side of the estimate", j)
+		}
+		z0 := norm.Quantile(float64(below) / float64(len(reps)))
+
+		var jackMean float64
+		for _, v := range jack {
+			jackMean += v[j]
+		}
+		jackMean /= float64(len(jack))
+		var num, den float64
+		for _, v := range jack {
+			d := jackMean - v[j]
+			num += d * d * d
+			den += d * d
+		}
+		var accel float64
+		if den > 0.0 {
+			accel = num / (6.0 * math.Pow(den, 1.5))
+		}
+
+		adjust := func(z float64) float64 {
+			return norm.CDF(z0 + (z0+z)/(1.0-accel*(z0+z)))
+		}
+		q, err := utils.Quantiles(reps, []float64{adjust(zLo), adjust(zHi)}, utils.Linear)
+		if err != nil {
+			return nil, err
+		}
+		intervals[j] = Interval{Lower: q[0], Upper: q[1]}
+	}
+	return intervals, nil
+}
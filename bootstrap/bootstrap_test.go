@@ -0,0 +1,158 @@
+package bootstrap
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+// exponential draws n skewed values with a fixed seed
+func exponential(n int) []float64 {
+	r := rand.New(rand.NewSource(1))
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = r.ExpFloat64()
+	}
+	return x
+}
+
+func TestRunScalar(t *testing.T) {
+	x := exponential(50)
+	res, err := Run(x, Scalar(utils.Mean[float64]), Options{Replicates: 2000, Seed: 7})
+	if err != nil {
+		t.Fatalf("error calling Run(x, Mean): %s", err)
+	}
+	if len(res.Replicates) != 2000 {
+		t.Fatalf("Run(x, Mean) gave %d replicates; want 2000", len(res.Replicates))
+	}
+	se, err := res.StdErr()
+	if err != nil {
+		t.Fatalf("error calling StdErr(): %s", err)
+	}
+	// the standard error of the mean is std / sqrt(n)
+	std, _ := utils.StandardDeviation(x)
+	if want := std / math.Sqrt(50.0); math.Abs(se[0]-want) > 0.1*want {
+		t.Fatalf("Run(x, Mean).StdErr() = %f; want about %f", se[0], want)
+	}
+
+	pct, err := res.Percentile(0.95)
+	if err != nil {
+		t.Fatalf("error calling Percentile(0.95): %s", err)
+	}
+	bca, err := res.BCa(0.95)
+	if err != nil {
+		t.Fatalf("error calling BCa(0.95): %s", err)
+	}
+	mu := res.Estimate[0]
+	if !(pct[0].Lower < mu && mu < pct[0].Upper) || !(bca[0].Lower < mu && mu < bca[0].Upper) {
+		t.Fatalf("intervals %v and %v should contain the estimate %f", pct[0], bca[0], mu)
+	}
+	// the mean of right skewed data has a right skewed sampling
+	// distribution, which BCa corrects for by shifting right
+	if bca[0].Lower <= pct[0].Lower || bca[0].Upper <= pct[0].Upper {
+		t.Fatalf("BCa interval %v should be right of percentile interval %v", bca[0], pct[0])
+	}
+	if _, err := res.Percentile(1.0); err == nil {
+		t.Fatalf("Percentile(1) should raise error: confidence level must be in (0, 1)")
+	}
+}
+
+func TestRunReproducible(t *testing.T) {
+	x := exponential(30)
+	stat := Scalar(utils.Median[float64])
+	serial, err := Run(x, stat, Options{Replicates: 500, Seed: 42, Workers: 1})
+	if err != nil {
+		t.Fatalf("error calling Run(x, Median, 1 worker): %s", err)
+	}
+	for _, workers := range []int{2, 4, 7} {
+		parallel, err := Run(x, stat, Options{Replicates: 500, Seed: 42, Workers: workers})
+		if err != nil {
+			t.Fatalf("error calling Run(x, Median, %d workers): %s", workers, err)
+		}
+		for b := range serial.Replicates {
+			if !utils.VectorsEqual(serial.Replicates[b], parallel.Replicates[b]) {
+				t.Fatalf("replicate %d with %d workers = %v; want %v",
+					b, workers, parallel.Replicates[b], serial.Replicates[b])
+			}
+		}
+	}
+	other, _ := Run(x, stat, Options{Replicates: 500, Seed: 43, Workers: 1})
+	if fmt.Sprint(other.Replicates) == fmt.Sprint(serial.Replicates) {
+		t.Fatalf("Run with seeds 42 and 43 gave the same replicates")
+	}
+}
+
+func TestRunVector(t *testing.T) {
+	// y = 2 + 3x + noise, fit by least squares on resampled rows
+	r := rand.New(rand.NewSource(3))
+	rows := make([][]float64, 100)
+	for i := range rows {
+		x := r.Float64() * 10.0
+		rows[i] = []float64{x, 2.0 + 3.0*x + r.NormFloat64()}
+	}
+	fit := func(sample [][]float64) ([]float64, error) {
+		x := make([][]float64, len(sample))
+		y := make([]float64, len(sample))
+		for i, row := range sample {
+			x[i] = []float64{1.0, row[0]}
+			y[i] = row[1]
+		}
+		xMat, err := utils.FromRows(x)
+		if err != nil {
+			return nil, err
+		}
+		return utils.LeastSquares(xMat, y)
+	}
+	res, err := Run(rows, fit, Options{Replicates: 500, Seed: 1})
+	if err != nil {
+		t.Fatalf("error calling Run(rows, LeastSquares): %s", err)
+	}
+	intervals, err := res.BCa(0.95)
+	if err != nil {
+		t.Fatalf("error calling BCa(0.95): %s", err)
+	}
+	for j, want := range []float64{2.0, 3.0} {
+		if !(intervals[j].Lower < want && want < intervals[j].Upper) {
+			t.Fatalf("BCa interval for beta[%d] = %v; want it to contain %f", j, intervals[j], want)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	stat := Scalar(utils.Mean[float64])
+	if _, err := Run([]float64{}, stat, Options{}); err == nil {
+		t.Fatalf("Run([], Mean) should raise error: need at least 1 values, got 0")
+	}
+	if _, err := Run([]float64{1.0, 2.0}, stat, Options{Replicates: 1}); err == nil {
+		t.Fatalf("Run([1, 2], Mean, 1 replicate) should raise error: need at least 2 replicates")
+	}
+	ragged := func(sample []float64) ([]float64, error) {
+		return make([]float64, int(sample[0])), nil
+	}
+	if _, err := Run([]float64{1.0, 2.0, 3.0}, ragged, Options{Seed: 1}); err == nil {
+		t.Fatalf("Run with a ragged statistic should raise error: statistic returned 2 values, want 1")
+	}
+	res, _ := Run([]float64{1.0, 1.0, 1.0}, stat, Options{Seed: 1})
+	if _, err := res.BCa(0.95); err == nil {
+		t.Fatalf("BCa(0.95) on constant data should raise error: bias correction is undefined")
+	}
+}
+
+func TestParallelMapLowestError(t *testing.T) {
+	// every odd index fails, and whichever goroutine
+	// finishes first the error of index 1 is returned
+	f := func(i int) ([]float64, error) {
+		if i%2 == 1 {
+			return nil, fmt.Errorf("replicate %d failed", i)
+		}
+		return []float64{float64(i)}, nil
+	}
+	for trial := 0; trial < 20; trial++ {
+		if _, err := parallelMap(100, 8, f); err == nil || err.Error() != "replicate 1 failed" {
+			t.Fatalf("parallelMap(100, 8, f) error = %v; want replicate 1 failed", err)
+		}
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"log"
 	"math"
 	"math/rand"
-	"time"
 
 	"github.com/dcooper46/go-ds-from-scratch/utils"
 )
@@ -38,10 +37,9 @@ func (km *KMeans) Classify(x []float64) int {
 }
 
 // Train takes input training data and determines the optimal
-// clusters based on the number requested
-func (km *KMeans) Train(data [][]float64) {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-
+// clusters based on the number requested, drawing the initial
+// cluster centers from r
+func (km *KMeans) Train(data [][]float64, r *rand.Rand) {
 	// initialize cluster centers with random elements from data
 	km.means = make([][]float64, km.k)
 	for i := 0; i < km.k; i++ {
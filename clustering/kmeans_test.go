@@ -0,0 +1,19 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestKMeansTrainReproducible(t *testing.T) {
+	a := KMeans{k: 3}
+	a.Train(x, rand.New(rand.NewSource(7)))
+	b := KMeans{k: 3}
+	b.Train(x, rand.New(rand.NewSource(7)))
+	for c := range a.means {
+		if !slices.Equal(a.means[c], b.means[c]) {
+			t.Fatalf("KMeans.Train with seed 7 means = %v, then %v; want equal", a.means, b.means)
+		}
+	}
+}
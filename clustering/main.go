@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"math/rand"
+)
 
 var x = [][]float64{
 	{-14.0, -5.0}, {13.0, 13.0}, {20.0, 23.0},
@@ -16,6 +19,6 @@ func main() {
 	fmt.Println("Clustering:")
 
 	km := KMeans{k: 3}
-	km.Train(x)
+	km.Train(x, rand.New(rand.NewSource(42)))
 	fmt.Printf("cluster centers: \n%v\n", km.means)
 }
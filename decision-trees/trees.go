@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -22,17 +23,21 @@ func Entropy(probs []float64) (entropy float64) {
 }
 
 // GetProbabilities returns label probabilities given a slice of labels (strings)
+// in sorted order of the labels, so results do not depend on map iteration
 func GetProbabilities(labels []string) []float64 {
 	n := float64(len(labels))
 	labelCounts := make(map[string]int)
 	for _, label := range labels {
 		labelCounts[label]++
 	}
-	counts := make([]float64, len(labelCounts))
-	var i int
-	for _, c := range labelCounts {
-		counts[i] = float64(c)
-		i++
+	keys := make([]string, 0, len(labelCounts))
+	for label := range labelCounts {
+		keys = append(keys, label)
+	}
+	sort.Strings(keys)
+	counts := make([]float64, len(keys))
+	for i, label := range keys {
+		counts[i] = float64(labelCounts[label])
 	}
 	return utils.ScalarMultiply(1.0/n, counts)
 }
@@ -52,7 +57,14 @@ func PartitionEntropy(subsets map[string][]map[string]string) float64 {
 	var N float64
 	var PartialH []float64
 
-	for _, s := range subsets {
+	// sum in sorted order so the result does not depend on map iteration
+	keys := make([]string, 0, len(subsets))
+	for k := range subsets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s := subsets[k]
 		N += float64(len(s))
 		vals := make([]string, len(s))
 		for i, v := range s {
@@ -198,3 +210,40 @@ func (tree *BoolTree) Show(tabs ...string) {
 	}
 	fmt.Println(strings.Join(append(tabs, "}"), ""))
 }
+
+// NumericAttribute parses a numeric attribute of every record
+func NumericAttribute(data []map[string]string, attribute string) ([]float64, error) {
+	values := make([]float64, len(data))
+	for i, row := range data {
+		v, err := strconv.ParseFloat(row[attribute], 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad value for %s in record %d: %s", attribute, i, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// DiscretizeAttribute returns copies of the records with a numeric attribute
+// replaced by the label of its bin between edges, such as those from
+// utils.QuantileEdges, so that ID3 can split on it
+func DiscretizeAttribute(data []map[string]string, attribute string, edges []float64) ([]map[string]string, error) {
+	values, err := NumericAttribute(data, attribute)
+	if err != nil {
+		return nil, err
+	}
+	hist, err := utils.NewHistogram(values, edges)
+	if err != nil {
+		return nil, err
+	}
+	labels := hist.Discretize(values)
+	out := make([]map[string]string, len(data))
+	for i, row := range data {
+		out[i] = make(map[string]string, len(row))
+		for k, v := range row {
+			out[i][k] = v
+		}
+		out[i][attribute] = labels[i]
+	}
+	return out, nil
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"math"
 	"testing"
+
+	"github.com/dcooper46/go-ds-from-scratch/utils"
 )
 
 func TestGetProbabilities(t *testing.T) {
@@ -25,3 +27,40 @@ func TestEntropy(t *testing.T) {
 		t.Errorf("wrong entropy! got %f, wanted %f", roundedActual, 1.883)
 	}
 }
+
+func TestDiscretizeAttribute(t *testing.T) {
+	data := []map[string]string{
+		{"years": "1", "label": "false"},
+		{"years": "2", "label": "false"},
+		{"years": "3.5", "label": "false"},
+		{"years": "6", "label": "true"},
+		{"years": "8", "label": "true"},
+		{"years": "12", "label": "true"},
+	}
+	years, err := NumericAttribute(data, "years")
+	if err != nil {
+		t.Fatalf("error calling NumericAttribute(data, years): %s", err)
+	}
+	edges, _ := utils.QuantileEdges(years, 2)
+	binned, err := DiscretizeAttribute(data, "years", edges)
+	if err != nil {
+		t.Fatalf("error calling DiscretizeAttribute(data, years, %v): %s", edges, err)
+	}
+	if binned[0]["years"] != "[1, 4.75)" || binned[5]["years"] != "[4.75, 12]" || data[0]["years"] != "1" {
+		t.Fatalf("DiscretizeAttribute(data, years) = %v; want years binned at 4.75", binned)
+	}
+
+	tree := BuildTreeID3(binned, []string{"years"})
+	hist, _ := utils.NewHistogram(years, edges)
+	for _, v := range []float64{2.5, 10.0} {
+		input := map[string]string{"years": hist.Discretize([]float64{v})[0]}
+		if actual := tree.Classify(input); actual != (v > 4.75) {
+			t.Errorf("Classify(years = %v) = %v; want %v", v, actual, v > 4.75)
+		}
+	}
+
+	data[2]["years"] = "many"
+	if _, err := DiscretizeAttribute(data, "years", edges); err == nil {
+		t.Errorf("DiscretizeAttribute with a non-numeric value should raise error: bad value for years")
+	}
+}
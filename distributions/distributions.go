@@ -0,0 +1,604 @@
+// Package distributions provides the probability distributions used in
+// the book's probability and statistics chapters.  Each distribution can
+// evaluate its density (or mass), cumulative distribution and inverse,
+// report its moments, and draw samples from a caller-supplied *rand.Rand
+// so results are reproducible.
+package distributions
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Continuous is a probability distribution over the real line
+type Continuous interface {
+	PDF(x float64) float64
+	CDF(x float64) float64
+	Quantile(p float64) float64
+	Mean() float64
+	Variance() float64
+	Rand(r *rand.Rand) float64
+}
+
+// Discrete is a probability distribution over the integers
+type Discrete interface {
+	PMF(k int) float64
+	CDF(k int) float64
+	Quantile(p float64) int
+	Mean() float64
+	Variance() float64
+	Rand(r *rand.Rand) int
+}
+
+// Normal is the gaussian distribution with mean Mu and standard deviation Sigma
+type Normal struct {
+	Mu    float64
+	Sigma float64
+}
+
+// StandardNormal is the normal distribution with mean 0 and standard deviation 1
+var StandardNormal = Normal{Mu: 0.0, Sigma: 1.0}
+
+// PDF returns the probability density at x
+func (d Normal) PDF(x float64) float64 {
+	z := (x - d.Mu) / d.Sigma
+	return math.Exp(-z*z/2.0) / (math.Sqrt(2.0*math.Pi) * d.Sigma)
+}
+
+// CDF returns the probability of a value at or below x
+func (d Normal) CDF(x float64) float64 {
+	return 0.5 * math.Erfc(-(x-d.Mu)/(d.Sigma*math.Sqrt2))
+}
+
+// Quantile returns the value with CDF equal to p
+func (d Normal) Quantile(p float64) float64 {
+	if p < 0.0 || p > 1.0 {
+		return math.NaN()
+	}
+	return d.Mu - d.Sigma*math.Sqrt2*math.Erfcinv(2.0*p)
+}
+
+// Mean returns the expected value
+func (d Normal) Mean() float64 {
+	return d.Mu
+}
+
+// Variance returns the expected squared deviation from the mean
+func (d Normal) Variance() float64 {
+	return d.Sigma * d.Sigma
+}
+
+// Rand draws a sample
+func (d Normal) Rand(r *rand.Rand) float64 {
+	return d.Mu + d.Sigma*r.NormFloat64()
+}
+
+// Uniform is the continuous uniform distribution on [Min, Max]
+type Uniform struct {
+	Min float64
+	Max float64
+}
+
+// PDF returns the probability density at x
+func (d Uniform) PDF(x float64) float64 {
+	if x < d.Min || x > d.Max {
+		return 0.0
+	}
+	return 1.0 / (d.Max - d.Min)
+}
+
+// CDF returns the probability of a value at or below x
+func (d Uniform) CDF(x float64) float64 {
+	switch {
+	case x <= d.Min:
+		return 0.0
+	case x >= d.Max:
+		return 1.0
+	}
+	return (x - d.Min) / (d.Max - d.Min)
+}
+
+// Quantile returns the value with CDF equal to p
+func (d Uniform) Quantile(p float64) float64 {
+	if p < 0.0 || p > 1.0 {
+		return math.NaN()
+	}
+	return d.Min + p*(d.Max-d.Min)
+}
+
+// Mean returns the expected value
+func (d Uniform) Mean() float64 {
+	return (d.Min + d.Max) / 2.0
+}
+
+// Variance returns the expected squared deviation from the mean
+func (d Uniform) Variance() float64 {
+	return (d.Max - d.Min) * (d.Max - d.Min) / 12.0
+}
+
+// Rand draws a sample
+func (d Uniform) Rand(r *rand.Rand) float64 {
+	return d.Min + r.Float64()*(d.Max-d.Min)
+}
+
+// Bernoulli is a single trial that is 1 with probability P and 0 otherwise
+type Bernoulli struct {
+	P float64
+}
+
+// PMF returns the probability of outcome k
+func (d Bernoulli) PMF(k int) float64 {
+	switch k {
+	case 0:
+		return 1.0 - d.P
+	case 1:
+		return d.P
+	}
+	return 0.0
+}
+
+// CDF returns the probability of an outcome at or below k
+func (d Bernoulli) CDF(k int) float64 {
+	switch {
+	case k < 0:
+		return 0.0
+	case k < 1:
+		return 1.0 - d.P
+	}
+	return 1.0
+}
+
+// Quantile returns the smallest outcome with CDF at least p
+func (d Bernoulli) Quantile(p float64) int {
+	if p <= 1.0-d.P {
+		return 0
+	}
+	return 1
+}
+
+// Mean returns the expected value
+func (d Bernoulli) Mean() float64 {
+	return d.P
+}
+
+// Variance returns the expected squared deviation from the mean
+func (d Bernoulli) Variance() float64 {
+	return d.P * (1.0 - d.P)
+}
+
+// Rand draws a sample
+func (d Bernoulli) Rand(r *rand.Rand) int {
+	if r.Float64() < d.P {
+		return 1
+	}
+	return 0
+}
+
+// Binomial is the number of successes in N independent trials
+// that each succeed with probability P
+type Binomial struct {
+	N int
+	P float64
+}
+
+// PMF returns the probability of exactly k successes
+func (d Binomial) PMF(k int) float64 {
+	if k < 0 || k > d.N {
+		return 0.0
+	}
+	switch d.P {
+	case 0.0:
+		if k == 0 {
+			return 1.0
+		}
+		return 0.0
+	case 1.0:
+		if k == d.N {
+			return 1.0
+		}
+		return 0.0
+	}
+	n, kf := float64(d.N), float64(k)
+	logChoose := lgamma(n+1.0) - lgamma(kf+1.0) - lgamma(n-kf+1.0)
+	return math.Exp(logChoose + kf*math.Log(d.P) + (n-kf)*math.Log1p(-d.P))
+}
+
+// CDF returns the probability of at most k successes
+func (d Binomial) CDF(k int) float64 {
+	switch {
+	case k < 0:
+		return 0.0
+	case k >= d.N:
+		return 1.0
+	}
+	return RegIncBeta(float64(d.N-k), float64(k+1), 1.0-d.P)
+}
+
+// Quantile returns the smallest number of successes with CDF at least p
+func (d Binomial) Quantile(p float64) int {
+	if p >= 1.0 {
+		return d.N
+	}
+	return discreteQuantile(d.CDF, p, 0)
+}
+
+// Mean returns the expected value
+func (d Binomial) Mean() float64 {
+	return float64(d.N) * d.P
+}
+
+// Variance returns the expected squared deviation from the mean
+func (d Binomial) Variance() float64 {
+	return float64(d.N) * d.P * (1.0 - d.P)
+}
+
+// Rand draws a sample by inverting the CDF
+func (d Binomial) Rand(r *rand.Rand) int {
+	return d.Quantile(r.Float64())
+}
+
+// Poisson is the number of events in an interval when events
+// occur independently at an average rate Lambda
+type Poisson struct {
+	Lambda float64
+}
+
+// PMF returns the probability of exactly k events
+func (d Poisson) PMF(k int) float64 {
+	if k < 0 {
+		return 0.0
+	}
+	// with no events expected there are always none
+	if d.Lambda == 0.0 {
+		if k == 0 {
+			return 1.0
+		}
+		return 0.0
+	}
+	kf := float64(k)
+	return math.Exp(kf*math.Log(d.Lambda) - d.Lambda - lgamma(kf+1.0))
+}
+
+// CDF returns the probability of at most k events
+func (d Poisson) CDF(k int) float64 {
+	if k < 0 {
+		return 0.0
+	}
+	return RegIncGammaUpper(float64(k+1), d.Lambda)
+}
+
+// Quantile returns the smallest number of events with CDF at least p.
+// The support is unbounded, so for p >= 1 it returns math.MaxInt
+// unless Lambda is 0.
+func (d Poisson) Quantile(p float64) int {
+	switch {
+	case p <= 0.0 || d.Lambda == 0.0:
+		return 0
+	case p >= 1.0:
+		return math.MaxInt
+	}
+	return discreteQuantile(d.CDF, p, 0)
+}
+
+// Mean returns the expected value
+func (d Poisson) Mean() float64 {
+	return d.Lambda
+}
+
+// Variance returns the expected squared deviation from the mean
+func (d Poisson) Variance() float64 {
+	return d.Lambda
+}
+
+// Rand draws a sample by inverting the CDF
+func (d Poisson) Rand(r *rand.Rand) int {
+	return d.Quantile(r.Float64())
+}
+
+// Beta is the distribution on [0, 1] with shape parameters Alpha and Beta
+type Beta struct {
+	Alpha float64
+	Beta  float64
+}
+
+// PDF returns the probability density at x
+func (d Beta) PDF(x float64) float64 {
+	if x < 0.0 || x > 1.0 {
+		return 0.0
+	}
+	// at the ends of the interval one log term is -Inf, and
+	// its exponent decides whether the density is 0 or unbounded
+	switch {
+	case x == 0.0 && d.Alpha > 1.0, x == 1.0 && d.Beta > 1.0:
+		return 0.0
+	case x == 0.0 && d.Alpha < 1.0, x == 1.0 && d.Beta < 1.0:
+		return math.Inf(1)
+	}
+	var a, b float64
+	if x > 0.0 {
+		a = (d.Alpha - 1.0) * math.Log(x)
+	}
+	if x < 1.0 {
+		b = (d.Beta - 1.0) * math.Log1p(-x)
+	}
+	return math.Exp(a + b - lbeta(d.Alpha, d.Beta))
+}
+
+// CDF returns the probability of a value at or below x
+func (d Beta) CDF(x float64) float64 {
+	return RegIncBeta(d.Alpha, d.Beta, x)
+}
+
+// Quantile returns the value with CDF equal to p, found by bisection
+func (d Beta) Quantile(p float64) float64 {
+	if p < 0.0 || p > 1.0 {
+		return math.NaN()
+	}
+	return bisect(d.CDF, p, 0.0, 1.0, false)
+}
+
+// Mean returns the expected value
+func (d Beta) Mean() float64 {
+	return d.Alpha / (d.Alpha + d.Beta)
+}
+
+// Variance returns the expected squared deviation from the mean
+func (d Beta) Variance() float64 {
+	ab := d.Alpha + d.Beta
+	return d.Alpha * d.Beta / (ab * ab * (ab + 1.0))
+}
+
+// Rand draws a sample from the ratio of two gamma variates
+func (d Beta) Rand(r *rand.Rand) float64 {
+	x := Gamma{Shape: d.Alpha, Rate: 1.0}.Rand(r)
+	y := Gamma{Shape: d.Beta, Rate: 1.0}.Rand(r)
+	return x / (x + y)
+}
+
+// Gamma is the distribution of positive values with shape parameter Shape
+// and rate parameter Rate (the inverse of the scale)
+type Gamma struct {
+	Shape float64
+	Rate  float64
+}
+
+// PDF returns the probability density at x
+func (d Gamma) PDF(x float64) float64 {
+	if x < 0.0 {
+		return 0.0
+	}
+	if x == 0.0 {
+		switch {
+		case d.Shape < 1.0:
+			return math.Inf(1)
+		case d.Shape == 1.0:
+			return d.Rate
+		}
+		return 0.0
+	}
+	return math.Exp(d.Shape*math.Log(d.Rate) + (d.Shape-1.0)*math.Log(x) - d.Rate*x - lgamma(d.Shape))
+}
+
+// CDF returns the probability of a value at or below x
+func (d Gamma) CDF(x float64) float64 {
+	return RegIncGamma(d.Shape, d.Rate*x)
+}
+
+// Quantile returns the value with CDF equal to p, found by bisection
+func (d Gamma) Quantile(p float64) float64 {
+	if p < 0.0 || p > 1.0 {
+		return math.NaN()
+	}
+	if p == 1.0 {
+		return math.Inf(1)
+	}
+	return bisect(d.CDF, p, 0.0, math.Max(d.Mean(), 1.0), true)
+}
+
+// Mean returns the expected value
+func (d Gamma) Mean() float64 {
+	return d.Shape / d.Rate
+}
+
+// Variance returns the expected squared deviation from the mean
+func (d Gamma) Variance() float64 {
+	return d.Shape / (d.Rate * d.Rate)
+}
+
+// Rand draws a sample using the Marsaglia and Tsang method
+func (d Gamma) Rand(r *rand.Rand) float64 {
+	shape := d.Shape
+	boost := 1.0
+	if shape < 1.0 {
+		// sample with shape+1 and scale down by U^(1/shape)
+		boost = math.Pow(r.Float64(), 1.0/shape)
+		shape++
+	}
+	dd := shape - 1.0/3.0
+	c := 1.0 / math.Sqrt(9.0*dd)
+	for {
+		x := r.NormFloat64()
+		v := 1.0 + c*x
+		if v <= 0.0 {
+			continue
+		}
+		v = v * v * v
+		u := r.Float64()
+		if u < 1.0-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+dd*(1.0-v+math.Log(v)) {
+			return boost * dd * v / d.Rate
+		}
+	}
+}
+
+// StudentT is Student's t distribution with Nu degrees of freedom
+type StudentT struct {
+	Nu float64
+}
+
+// PDF returns the probability density at x
+func (d StudentT) PDF(x float64) float64 {
+	nu := d.Nu
+	return math.Exp(lgamma((nu+1.0)/2.0)-lgamma(nu/2.0)-0.5*math.Log(nu*math.Pi)) *
+		math.Pow(1.0+x*x/nu, -(nu+1.0)/2.0)
+}
+
+// CDF returns the probability of a value at or below x
+func (d StudentT) CDF(x float64) float64 {
+	tail := 0.5 * RegIncBeta(d.Nu/2.0, 0.5, d.Nu/(d.Nu+x*x))
+	if x > 0.0 {
+		return 1.0 - tail
+	}
+	return tail
+}
+
+// Quantile returns the value with CDF equal to p, found by bisection
+func (d StudentT) Quantile(p float64) float64 {
+	switch {
+	case p < 0.0 || p > 1.0:
+		return math.NaN()
+	case p == 0.0:
+		return math.Inf(-1)
+	case p == 1.0:
+		return math.Inf(1)
+	case p < 0.5:
+		return -d.Quantile(1.0 - p)
+	}
+	return bisect(d.CDF, p, 0.0, 1.0, true)
+}
+
+// Mean returns the expected value, which is undefined for Nu <= 1
+func (d StudentT) Mean() float64 {
+	if d.Nu <= 1.0 {
+		return math.NaN()
+	}
+	return 0.0
+}
+
+// Variance returns the expected squared deviation from the mean,
+// which is infinite for 1 < Nu <= 2 and undefined for Nu <= 1
+func (d StudentT) Variance() float64 {
+	switch {
+	case d.Nu <= 1.0:
+		return math.NaN()
+	case d.Nu <= 2.0:
+		return math.Inf(1)
+	}
+	return d.Nu / (d.Nu - 2.0)
+}
+
+// Rand draws a sample
+func (d StudentT) Rand(r *rand.Rand) float64 {
+	z := r.NormFloat64()
+	v := ChiSquared{K: d.Nu}.Rand(r)
+	return z / math.Sqrt(v/d.Nu)
+}
+
+// ChiSquared is the distribution of a sum of K squared standard normals
+type ChiSquared struct {
+	K float64
+}
+
+// gamma returns the equivalent gamma distribution
+func (d ChiSquared) gamma() Gamma {
+	return Gamma{Shape: d.K / 2.0, Rate: 0.5}
+}
+
+// PDF returns the probability density at x
+func (d ChiSquared) PDF(x float64) float64 {
+	return d.gamma().PDF(x)
+}
+
+// CDF returns the probability of a value at or below x
+func (d ChiSquared) CDF(x float64) float64 {
+	return d.gamma().CDF(x)
+}
+
+// Quantile returns the value with CDF equal to p, found by bisection
+func (d ChiSquared) Quantile(p float64) float64 {
+	return d.gamma().Quantile(p)
+}
+
+// Mean returns the expected value
+func (d ChiSquared) Mean() float64 {
+	return d.K
+}
+
+// Variance returns the expected squared deviation from the mean
+func (d ChiSquared) Variance() float64 {
+	return 2.0 * d.K
+}
+
+// Rand draws a sample
+func (d ChiSquared) Rand(r *rand.Rand) float64 {
+	return d.gamma().Rand(r)
+}
+
+// F is Snedecor's F distribution, the ratio of two chi-squared variables
+// with D1 and D2 degrees of freedom each divided by its degrees of freedom
+type F struct {
+	D1 float64
+	D2 float64
+}
+
+// PDF returns the probability density at x
+func (d F) PDF(x float64) float64 {
+	if x < 0.0 {
+		return 0.0
+	}
+	if x == 0.0 {
+		switch {
+		case d.D1 < 2.0:
+			return math.Inf(1)
+		case d.D1 == 2.0:
+			return 1.0
+		}
+		return 0.0
+	}
+	logp := 0.5*(d.D1*math.Log(d.D1*x)+d.D2*math.Log(d.D2)-(d.D1+d.D2)*math.Log(d.D1*x+d.D2)) -
+		math.Log(x) - lbeta(d.D1/2.0, d.D2/2.0)
+	return math.Exp(logp)
+}
+
+// CDF returns the probability of a value at or below x
+func (d F) CDF(x float64) float64 {
+	if x <= 0.0 {
+		return 0.0
+	}
+	return RegIncBeta(d.D1/2.0, d.D2/2.0, d.D1*x/(d.D1*x+d.D2))
+}
+
+// Quantile returns the value with CDF equal to p, found by bisection
+func (d F) Quantile(p float64) float64 {
+	if p < 0.0 || p > 1.0 {
+		return math.NaN()
+	}
+	if p == 1.0 {
+		return math.Inf(1)
+	}
+	return bisect(d.CDF, p, 0.0, 1.0, true)
+}
+
+// Mean returns the expected value, which is undefined for D2 <= 2
+func (d F) Mean() float64 {
+	if d.D2 <= 2.0 {
+		return math.NaN()
+	}
+	return d.D2 / (d.D2 - 2.0)
+}
+
+// Variance returns the expected squared deviation from the mean,
+// which is undefined for D2 <= 4
+func (d F) Variance() float64 {
+	if d.D2 <= 4.0 {
+		return math.NaN()
+	}
+	return 2.0 * d.D2 * d.D2 * (d.D1 + d.D2 - 2.0) /
+		(d.D1 * (d.D2 - 2.0) * (d.D2 - 2.0) * (d.D2 - 4.0))
+}
+
+// Rand draws a sample
+func (d F) Rand(r *rand.Rand) float64 {
+	x := ChiSquared{K: d.D1}.Rand(r) / d.D1
+	y := ChiSquared{K: d.D2}.Rand(r) / d.D2
+	return x / y
+}
@@ -0,0 +1,254 @@
+package distributions
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+func round3(x float64) float64 {
+	return math.Round(x*1000) / 1000
+}
+
+func TestNormal(t *testing.T) {
+	if actual := round3(StandardNormal.CDF(1.96)); actual != 0.975 {
+		t.Fatalf("StandardNormal.CDF(1.96) = %f; want 0.975", actual)
+	}
+	if actual := round3(StandardNormal.Quantile(0.975)); actual != 1.96 {
+		t.Fatalf("StandardNormal.Quantile(0.975) = %f; want 1.96", actual)
+	}
+	if actual := round3(StandardNormal.PDF(0.0)); actual != 0.399 {
+		t.Fatalf("StandardNormal.PDF(0) = %f; want 0.399", actual)
+	}
+	d := Normal{Mu: 10.0, Sigma: 2.0}
+	if actual := d.CDF(10.0); actual != 0.5 {
+		t.Fatalf("Normal{10, 2}.CDF(10) = %f; want 0.5", actual)
+	}
+	if actual := d.Quantile(1.5); !math.IsNaN(actual) {
+		t.Fatalf("Normal{10, 2}.Quantile(1.5) = %f; want NaN", actual)
+	}
+}
+
+func TestUniform(t *testing.T) {
+	d := Uniform{Min: 2.0, Max: 6.0}
+	if actual := d.CDF(3.0); actual != 0.25 {
+		t.Fatalf("Uniform{2, 6}.CDF(3) = %f; want 0.25", actual)
+	}
+	if actual := d.Quantile(0.75); actual != 5.0 {
+		t.Fatalf("Uniform{2, 6}.Quantile(0.75) = %f; want 5.0", actual)
+	}
+	if actual := round3(d.Variance()); actual != 1.333 {
+		t.Fatalf("Uniform{2, 6}.Variance() = %f; want 1.333", actual)
+	}
+}
+
+func TestBernoulli(t *testing.T) {
+	d := Bernoulli{P: 0.3}
+	if actual := d.PMF(0); actual != 0.7 {
+		t.Fatalf("Bernoulli{0.3}.PMF(0) = %f; want 0.7", actual)
+	}
+	if actual := d.Quantile(0.5); actual != 0 {
+		t.Fatalf("Bernoulli{0.3}.Quantile(0.5) = %d; want 0", actual)
+	}
+	if actual := d.Quantile(0.8); actual != 1 {
+		t.Fatalf("Bernoulli{0.3}.Quantile(0.8) = %d; want 1", actual)
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	d := Binomial{N: 10, P: 0.3}
+	if actual := round3(d.PMF(3)); actual != 0.267 {
+		t.Fatalf("Binomial{10, 0.3}.PMF(3) = %f; want 0.267", actual)
+	}
+	if actual := round3(d.CDF(3)); actual != 0.65 {
+		t.Fatalf("Binomial{10, 0.3}.CDF(3) = %f; want 0.65", actual)
+	}
+	if actual := d.Quantile(0.65); actual != 4 {
+		t.Fatalf("Binomial{10, 0.3}.Quantile(0.65) = %d; want 4", actual)
+	}
+	if actual := d.Quantile(0.5); actual != 3 {
+		t.Fatalf("Binomial{10, 0.3}.Quantile(0.5) = %d; want 3", actual)
+	}
+	if actual := d.Quantile(1.0); actual != 10 {
+		t.Fatalf("Binomial{10, 0.3}.Quantile(1) = %d; want 10", actual)
+	}
+}
+
+func TestPoisson(t *testing.T) {
+	d := Poisson{Lambda: 3.0}
+	if actual := round3(d.PMF(2)); actual != 0.224 {
+		t.Fatalf("Poisson{3}.PMF(2) = %f; want 0.224", actual)
+	}
+	if actual := round3(d.CDF(2)); actual != 0.423 {
+		t.Fatalf("Poisson{3}.CDF(2) = %f; want 0.423", actual)
+	}
+	if actual := d.Quantile(0.9); actual != 5 {
+		t.Fatalf("Poisson{3}.Quantile(0.9) = %d; want 5", actual)
+	}
+	if actual := d.Quantile(1.0); actual != math.MaxInt {
+		t.Fatalf("Poisson{3}.Quantile(1) = %d; want math.MaxInt", actual)
+	}
+	if actual := d.Quantile(0.0); actual != 0 {
+		t.Fatalf("Poisson{3}.Quantile(0) = %d; want 0", actual)
+	}
+	// a far lower tail, which 1 - P(k+1, lambda) rounds to 0
+	if actual := (Poisson{Lambda: 100.0}).CDF(10); math.Abs(actual-1.13769e-30)/1.13769e-30 > 1e-4 {
+		t.Fatalf("Poisson{100}.CDF(10) = %g; want 1.13769e-30", actual)
+	}
+
+	none := Poisson{Lambda: 0.0}
+	if actual := none.PMF(0); actual != 1.0 {
+		t.Fatalf("Poisson{0}.PMF(0) = %f; want 1", actual)
+	}
+	if actual := none.PMF(2); actual != 0.0 {
+		t.Fatalf("Poisson{0}.PMF(2) = %f; want 0", actual)
+	}
+	if actual := none.CDF(0); actual != 1.0 {
+		t.Fatalf("Poisson{0}.CDF(0) = %f; want 1", actual)
+	}
+	if actual := none.Quantile(1.0); actual != 0 {
+		t.Fatalf("Poisson{0}.Quantile(1) = %d; want 0", actual)
+	}
+}
+
+func TestBeta(t *testing.T) {
+	d := Beta{Alpha: 2.0, Beta: 5.0}
+	if actual := round3(d.CDF(0.3)); actual != 0.58 {
+		t.Fatalf("Beta{2, 5}.CDF(0.3) = %f; want 0.58", actual)
+	}
+	if actual := round3(d.Quantile(d.CDF(0.3))); actual != 0.3 {
+		t.Fatalf("Beta{2, 5}.Quantile(0.58) = %f; want 0.3", actual)
+	}
+	if actual := round3(d.PDF(0.2)); actual != 2.458 {
+		t.Fatalf("Beta{2, 5}.PDF(0.2) = %f; want 2.458", actual)
+	}
+
+	// densities at the ends of the interval
+	for _, test := range []struct {
+		d       Beta
+		x, want float64
+	}{
+		{Beta{1.0, 1.0}, 0.0, 1.0},
+		{Beta{1.0, 1.0}, 1.0, 1.0},
+		{Beta{2.0, 1.0}, 1.0, 2.0},
+		{Beta{2.0, 1.0}, 0.0, 0.0},
+		{Beta{2.0, 5.0}, 1.0, 0.0},
+		{Beta{0.5, 0.5}, 0.0, math.Inf(1)},
+		{Beta{0.5, 0.5}, 1.0, math.Inf(1)},
+	} {
+		if actual := test.d.PDF(test.x); round3(actual) != test.want {
+			t.Fatalf("Beta{%v, %v}.PDF(%v) = %f; want %v", test.d.Alpha, test.d.Beta, test.x, actual, test.want)
+		}
+	}
+}
+
+func TestGamma(t *testing.T) {
+	d := Gamma{Shape: 2.0, Rate: 3.0}
+	if actual := round3(d.CDF(2.0)); actual != 0.983 {
+		t.Fatalf("Gamma{2, 3}.CDF(2) = %f; want 0.983", actual)
+	}
+	if actual := round3(d.Quantile(d.CDF(0.5))); actual != 0.5 {
+		t.Fatalf("Gamma{2, 3}.Quantile(CDF(0.5)) = %f; want 0.5", actual)
+	}
+	// Q(1/2, x) = erfc(sqrt(x)), whose tail 1 - P(1/2, x) cannot reach
+	for _, x := range []float64{0.5, 1.5, 50.0} {
+		want := math.Erfc(math.Sqrt(x))
+		if actual := RegIncGammaUpper(0.5, x); math.Abs(actual-want) > 1e-12*want {
+			t.Fatalf("RegIncGammaUpper(0.5, %v) = %g; want %g", x, actual, want)
+		}
+	}
+}
+
+func TestStudentT(t *testing.T) {
+	d := StudentT{Nu: 10.0}
+	if actual := round3(d.Quantile(0.975)); actual != 2.228 {
+		t.Fatalf("StudentT{10}.Quantile(0.975) = %f; want 2.228", actual)
+	}
+	if actual := round3(d.Quantile(0.025)); actual != -2.228 {
+		t.Fatalf("StudentT{10}.Quantile(0.025) = %f; want -2.228", actual)
+	}
+	if actual := round3(d.CDF(2.228)); actual != 0.975 {
+		t.Fatalf("StudentT{10}.CDF(2.228) = %f; want 0.975", actual)
+	}
+	if actual := (StudentT{Nu: 1.0}).Mean(); !math.IsNaN(actual) {
+		t.Fatalf("StudentT{1}.Mean() = %f; want NaN", actual)
+	}
+}
+
+func TestChiSquared(t *testing.T) {
+	d := ChiSquared{K: 3.0}
+	if actual := round3(d.Quantile(0.95)); actual != 7.815 {
+		t.Fatalf("ChiSquared{3}.Quantile(0.95) = %f; want 7.815", actual)
+	}
+	if actual := round3(d.CDF(7.815)); actual != 0.95 {
+		t.Fatalf("ChiSquared{3}.CDF(7.815) = %f; want 0.95", actual)
+	}
+}
+
+func TestF(t *testing.T) {
+	d := F{D1: 3.0, D2: 10.0}
+	if actual := round3(d.Quantile(0.95)); actual != 3.708 {
+		t.Fatalf("F{3, 10}.Quantile(0.95) = %f; want 3.708", actual)
+	}
+	if actual := round3(d.CDF(3.708)); actual != 0.95 {
+		t.Fatalf("F{3, 10}.CDF(3.708) = %f; want 0.95", actual)
+	}
+	if actual := round3((F{D1: 1.0, D2: 20.0}).Quantile(0.95)); actual != 4.351 {
+		t.Fatalf("F{1, 20}.Quantile(0.95) = %f; want 4.351", actual)
+	}
+}
+
+func TestRand(t *testing.T) {
+	continuous := map[string]Continuous{
+		"Normal":     Normal{Mu: 5.0, Sigma: 2.0},
+		"Uniform":    Uniform{Min: -1.0, Max: 3.0},
+		"Beta":       Beta{Alpha: 2.0, Beta: 5.0},
+		"Gamma":      Gamma{Shape: 0.5, Rate: 2.0},
+		"StudentT":   StudentT{Nu: 10.0},
+		"ChiSquared": ChiSquared{K: 4.0},
+		"F":          F{D1: 5.0, D2: 20.0},
+	}
+	for name, d := range continuous {
+		r := rand.New(rand.NewSource(42))
+		samples := make([]float64, 20000)
+		for i := range samples {
+			samples[i] = d.Rand(r)
+		}
+		checkMoments(t, name, samples, d.Mean(), d.Variance())
+	}
+
+	discrete := map[string]Discrete{
+		"Bernoulli": Bernoulli{P: 0.3},
+		"Binomial":  Binomial{N: 20, P: 0.4},
+		"Poisson":   Poisson{Lambda: 4.5},
+	}
+	for name, d := range discrete {
+		r := rand.New(rand.NewSource(42))
+		samples := make([]float64, 20000)
+		for i := range samples {
+			samples[i] = float64(d.Rand(r))
+		}
+		checkMoments(t, name, samples, d.Mean(), d.Variance())
+	}
+}
+
+// checkMoments compares sample moments to the distribution's within 5%
+func checkMoments(t *testing.T, name string, samples []float64, mean, variance float64) {
+	t.Helper()
+	mu, err := utils.Mean(samples)
+	if err != nil {
+		t.Fatalf("error calling Mean on %s samples: %s", name, err)
+	}
+	v, err := utils.Variance(samples)
+	if err != nil {
+		t.Fatalf("error calling Variance on %s samples: %s", name, err)
+	}
+	if math.Abs(mu-mean) > 0.05*math.Max(math.Sqrt(variance), math.Abs(mean)) {
+		t.Fatalf("%s sample mean = %f; want %f", name, mu, mean)
+	}
+	if math.Abs(v-variance) > 0.05*variance {
+		t.Fatalf("%s sample variance = %f; want %f", name, v, variance)
+	}
+}
@@ -0,0 +1,30 @@
+package distributions
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStudentizedRange(t *testing.T) {
+	cases := []struct {
+		k, df, p, q float64
+	}{
+		{3.0, 10.0, 0.95, 3.877},
+		{4.0, 20.0, 0.95, 3.958},
+		{5.0, 60.0, 0.99, 4.818},
+		{2.0, 1e6, 0.95, 2.772},
+	}
+	for _, c := range cases {
+		d := StudentizedRange{K: c.k, DF: c.df}
+		name := fmt.Sprintf("StudentizedRange{%g, %g}", c.k, c.df)
+		if actual := round3(d.Quantile(c.p)); actual != c.q {
+			t.Fatalf("%s.Quantile(%g) = %f; want %g", name, c.p, actual, c.q)
+		}
+		if actual := round3(d.CDF(c.q)); actual != c.p {
+			t.Fatalf("%s.CDF(%g) = %f; want %g", name, c.q, actual, c.p)
+		}
+	}
+	if actual := (StudentizedRange{K: 3.0, DF: 10.0}).CDF(0.0); actual != 0.0 {
+		t.Fatalf("StudentizedRange{3, 10}.CDF(0) = %f; want 0", actual)
+	}
+}
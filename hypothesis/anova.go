@@ -0,0 +1,276 @@
+package hypothesis
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/dcooper46/go-ds-from-scratch/distributions"
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+// ANOVARow is one source of variation in an analysis of variance table
+type ANOVARow struct {
+	Source string
+	DF     float64
+	SumSq  float64
+	MeanSq float64
+	// F and PValue are NaN for the residuals row
+	F      float64
+	PValue float64
+}
+
+// ANOVATable partitions the total sum of squares between the sources
+// of variation, with the residuals as the last row
+type ANOVATable struct {
+	Rows []ANOVARow
+}
+
+// Residuals returns the residuals row
+func (t *ANOVATable) Residuals() ANOVARow {
+	return t.Rows[len(t.Rows)-1]
+}
+
+// String formats the table like R's summary of an aov fit
+func (t *ANOVATable) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-12s %4s %12s %12s %10s %10s", "", "Df", "Sum Sq", "Mean Sq", "F value", "Pr(>F)")
+	for _, r := range t.Rows {
+		fmt.Fprintf(&sb, "\n%-12s %4g %12.4f %12.4f", r.Source, r.DF, r.SumSq, r.MeanSq)
+		if !math.IsNaN(r.F) {
+			fmt.Fprintf(&sb, " %10.4f %10.4g", r.F, r.PValue)
+		}
+	}
+	return sb.String()
+}
+
+// newANOVATable fills in the mean squares, F statistics and p-values
+// given each source's degrees of freedom and sum of squares
+func newANOVATable(sources []string, dfs, sumSqs []float64) *ANOVATable {
+	last := len(sources) - 1
+	mse := sumSqs[last] / dfs[last]
+	table := &ANOVATable{Rows: make([]ANOVARow, len(sources))}
+	for i, source := range sources {
+		row := ANOVARow{
+			Source: source,
+			DF:     dfs[i],
+			SumSq:  sumSqs[i],
+			MeanSq: sumSqs[i] / dfs[i],
+			F:      math.NaN(),
+			PValue: math.NaN(),
+		}
+		if i < last {
+			row.F = row.MeanSq / mse
+			// upper tail of the F distribution, without the
+			// cancellation of 1 - CDF for very small p-values
+			d1, d2 := dfs[i], dfs[last]
+			row.PValue = distributions.RegIncBeta(d2/2.0, d1/2.0, d2/(d2+d1*row.F))
+		}
+		table.Rows[i] = row
+	}
+	return table
+}
+
+// levels returns the distinct values of a factor in increasing order
+func levels[K cmp.Ordered](factor []K) []K {
+	lv := slices.Clone(factor)
+	slices.Sort(lv)
+	return slices.Compact(lv)
+}
+
+// groupBy splits y by the level of a factor, in level order
+func groupBy[K cmp.Ordered](y []float64, factor []K) ([]K, [][]float64, error) {
+	if len(y) != len(factor) {
+		return nil, nil, fmt.Errorf("vectors are of unequal size: %d != %d", len(y), len(factor))
+	}
+	lv := levels(factor)
+	groups := make([][]float64, len(lv))
+	for i, f := range factor {
+		g, _ := slices.BinarySearch(lv, f)
+		groups[g] = append(groups[g], y[i])
+	}
+	return lv, groups, nil
+}
+
+// OneWayANOVA tests whether the mean of y is the same at every
+// level of a grouping factor
+func OneWayANOVA[K cmp.Ordered](y []float64, groups []K) (*ANOVATable, error) {
+	_, byGroup, err := groupBy(y, groups)
+	if err != nil {
+		return nil, err
+	}
+	k, n := len(byGroup), len(y)
+	if k < 2 {
+		return nil, fmt.Errorf("need at least 2 groups, got %d", k)
+	}
+	if n-k < 1 {
+		return nil, fmt.Errorf("need more values than groups, got %d values in %d groups", n, k)
+	}
+	tss, err := utils.TotalSumOfSquares(y)
+	if err != nil {
+		return nil, err
+	}
+	var within float64
+	for _, g := range byGroup {
+		ss, _ := utils.TotalSumOfSquares(g)
+		within += ss
+	}
+	return newANOVATable(
+		[]string{"group", "Residuals"},
+		[]float64{float64(k - 1), float64(n - k)},
+		[]float64{tss - within, within},
+	), nil
+}
+
+// dummies returns treatment coded indicator columns for a factor,
+// leaving out its first level
+func dummies[K cmp.Ordered](factor []K) [][]float64 {
+	lv := levels(factor)
+	cols := make([][]float64, len(lv)-1)
+	for j := range cols {
+		cols[j] = make([]float64, len(factor))
+	}
+	for i, f := range factor {
+		if g, _ := slices.BinarySearch(lv, f); g > 0 {
+			cols[g-1][i] = 1.0
+		}
+	}
+	return cols
+}
+
+// residualSS fits y on the given columns plus an intercept
+// by least squares and returns the residual sum of squares
+func residualSS(y []float64, cols [][]float64) (float64, error) {
+	x := make([][]float64, len(y))
+	for i := range x {
+		x[i] = make([]float64, len(cols)+1)
+		x[i][0] = 1.0
+		for j, c := range cols {
+			x[i][j+1] = c[i]
+		}
+	}
+	xMat, err := utils.FromRows(x)
+	if err != nil {
+		return 0.0, err
+	}
+	beta, err := utils.LeastSquares(xMat, y)
+	if err != nil {
+		return 0.0, err
+	}
+	fitted, err := xMat.MulVec(beta)
+	if err != nil {
+		return 0.0, err
+	}
+	return utils.SquaredDistance(y, fitted)
+}
+
+// TwoWayANOVA tests the main effects of two factors a and b on the mean
+// of y and their interaction.  Sums of squares are sequential (type I),
+// so for unbalanced designs the effect of b is adjusted for a but not the
+// other way around.  Every combination of levels must be observed.
+func TwoWayANOVA[A, B cmp.Ordered](y []float64, a []A, b []B) (*ANOVATable, error) {
+	if len(y) != len(a) || len(y) != len(b) {
+		return nil, fmt.Errorf("vectors are of unequal size: %d, %d, %d", len(y), len(a), len(b))
+	}
+	da, db := dummies(a), dummies(b)
+	if len(da) < 1 || len(db) < 1 {
+		return nil, fmt.Errorf("need at least 2 levels of each factor, got %d and %d", len(da)+1, len(db)+1)
+	}
+	var inter [][]float64
+	for _, ca := range da {
+		for _, cb := range db {
+			col := make([]float64, len(y))
+			for i := range col {
+				col[i] = ca[i] * cb[i]
+			}
+			inter = append(inter, col)
+		}
+	}
+	dfs := []float64{float64(len(da)), float64(len(db)), float64(len(inter))}
+	dfRes := float64(len(y)) - 1.0 - dfs[0] - dfs[1] - dfs[2]
+	if dfRes < 1.0 {
+		return nil, fmt.Errorf("need replicates within cells, got %d values for %d cells",
+			len(y), (len(da)+1)*(len(db)+1))
+	}
+
+	// residual sums of squares of the nested models
+	tss, err := utils.TotalSumOfSquares(y)
+	if err != nil {
+		return nil, err
+	}
+	rss := []float64{tss}
+	cols := [][]float64{}
+	for _, term := range [][][]float64{da, db, inter} {
+		cols = append(cols, term...)
+		ss, err := residualSS(y, cols)
+		if err != nil {
+			return nil, fmt.Errorf("every combination of levels must be observed: %s", err)
+		}
+		rss = append(rss, ss)
+	}
+	return newANOVATable(
+		[]string{"a", "b", "a:b", "Residuals"},
+		append(dfs, dfRes),
+		[]float64{rss[0] - rss[1], rss[1] - rss[2], rss[2] - rss[3], rss[3]},
+	), nil
+}
+
+// TukeyComparison is one pairwise comparison of group means
+type TukeyComparison[K cmp.Ordered] struct {
+	// Diff is the mean of group B minus the mean of group A
+	A, B K
+	Diff float64
+	// Lower and Upper bound the simultaneous confidence interval for Diff
+	Lower float64
+	Upper float64
+	// PValue is adjusted for the number of comparisons
+	PValue float64
+}
+
+// String formats the comparison like one row of R's TukeyHSD
+func (c TukeyComparison[K]) String() string {
+	return fmt.Sprintf("%v-%v diff %.6f lwr %.6f upr %.6f p adj %.6f",
+		c.B, c.A, c.Diff, c.Lower, c.Upper, c.PValue)
+}
+
+// TukeyHSD compares the mean of y between every pair of groups using
+// Tukey's honest significant difference, with simultaneous confidence
+// intervals at the given level.  It is the usual follow up to a
+// significant one-way ANOVA, and uses the Tukey-Kramer adjustment
+// when group sizes differ.
+func TukeyHSD[K cmp.Ordered](y []float64, groups []K, level float64) ([]TukeyComparison[K], error) {
+	if err := checkLevel(level); err != nil {
+		return nil, err
+	}
+	table, err := OneWayANOVA(y, groups)
+	if err != nil {
+		return nil, err
+	}
+	lv, byGroup, _ := groupBy(y, groups)
+	means := make([]float64, len(byGroup))
+	for i, g := range byGroup {
+		means[i], _ = utils.Mean(g)
+	}
+	res := table.Residuals()
+	dist := distributions.StudentizedRange{K: float64(len(lv)), DF: res.DF}
+	q := dist.Quantile(level)
+
+	var comparisons []TukeyComparison[K]
+	for i := range lv {
+		for j := i + 1; j < len(lv); j++ {
+			se := math.Sqrt(res.MeanSq / 2.0 * (1.0/float64(len(byGroup[i])) + 1.0/float64(len(byGroup[j]))))
+			diff := means[j] - means[i]
+			comparisons = append(comparisons, TukeyComparison[K]{
+				A:      lv[i],
+				B:      lv[j],
+				Diff:   diff,
+				Lower:  diff - q*se,
+				Upper:  diff + q*se,
+				PValue: 1.0 - dist.CDF(math.Abs(diff)/se),
+			})
+		}
+	}
+	return comparisons, nil
+}
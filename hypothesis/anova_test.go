@@ -0,0 +1,129 @@
+package hypothesis
+
+import (
+	"math"
+	"testing"
+)
+
+// plantGrowth is R's PlantGrowth data set, dried plant weights
+// under a control and two treatments
+var plantGrowth = []float64{
+	4.17, 5.58, 5.18, 6.11, 4.50, 4.61, 5.17, 4.53, 5.33, 5.14,
+	4.81, 4.17, 4.41, 3.59, 5.87, 3.83, 6.03, 4.89, 4.32, 4.69,
+	6.31, 5.12, 5.54, 5.50, 5.37, 5.29, 4.92, 6.15, 5.80, 5.26,
+}
+
+// toothGrowth is R's ToothGrowth data set, odontoblast lengths by
+// supplement type and vitamin C dose, 10 guinea pigs per cell
+var toothGrowth = []float64{
+	4.2, 11.5, 7.3, 5.8, 6.4, 10, 11.2, 11.2, 5.2, 7,
+	16.5, 16.5, 15.2, 17.3, 22.5, 17.3, 13.6, 14.5, 18.8, 15.5,
+	23.6, 18.5, 33.9, 25.5, 26.4, 32.5, 26.7, 21.5, 23.3, 29.5,
+	15.2, 21.5, 17.6, 9.7, 14.5, 10, 8.2, 9.4, 16.5, 9.7,
+	19.7, 23.3, 23.6, 26.4, 20, 25.2, 25.8, 21.2, 14.5, 27.3,
+	25.5, 26.4, 22.4, 24.5, 24.8, 30.9, 26.4, 27.3, 29.4, 23,
+}
+
+func plantGroups() []string {
+	var groups []string
+	for _, g := range []string{"ctrl", "trt1", "trt2"} {
+		for i := 0; i < 10; i++ {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+func toothFactors() (supp []string, dose []float64) {
+	for _, s := range []string{"VC", "OJ"} {
+		for _, d := range []float64{0.5, 1.0, 2.0} {
+			for i := 0; i < 10; i++ {
+				supp = append(supp, s)
+				dose = append(dose, d)
+			}
+		}
+	}
+	return supp, dose
+}
+
+func TestOneWayANOVA(t *testing.T) {
+	table, err := OneWayANOVA(plantGrowth, plantGroups())
+	if err != nil {
+		t.Fatalf("error calling OneWayANOVA(PlantGrowth): %s", err)
+	}
+	group, res := table.Rows[0], table.Residuals()
+	if group.DF != 2.0 || round4(group.SumSq) != 3.7663 || round4(group.F) != 4.8461 || round4(group.PValue) != 0.0159 {
+		t.Fatalf("OneWayANOVA(PlantGrowth) group = %+v; want df 2, SS 3.7663, F 4.8461, p 0.0159", group)
+	}
+	if res.DF != 27.0 || round4(res.SumSq) != 10.4921 || !math.IsNaN(res.F) {
+		t.Fatalf("OneWayANOVA(PlantGrowth) residuals = %+v; want df 27, SS 10.4921, F NaN", res)
+	}
+	if _, err := OneWayANOVA([]float64{1.0, 2.0}, []string{"a", "a"}); err == nil {
+		t.Fatalf("OneWayANOVA([1, 2], [a, a]) should raise error: need at least 2 groups, got 1")
+	}
+	if _, err := OneWayANOVA([]float64{1.0, 2.0}, []string{"a"}); err == nil {
+		t.Fatalf("OneWayANOVA([1, 2], [a]) should raise error: vectors are of unequal size")
+	}
+}
+
+func TestTwoWayANOVA(t *testing.T) {
+	supp, dose := toothFactors()
+	table, err := TwoWayANOVA(toothGrowth, supp, dose)
+	if err != nil {
+		t.Fatalf("error calling TwoWayANOVA(ToothGrowth): %s", err)
+	}
+	want := []struct{ df, ss, f float64 }{
+		{1.0, 205.35, 15.572},
+		{2.0, 2426.4343, 92.0},
+		{2.0, 108.319, 4.107},
+		{54.0, 712.106, math.NaN()},
+	}
+	for i, w := range want {
+		r := table.Rows[i]
+		if r.DF != w.df || round4(r.SumSq) != w.ss || (!math.IsNaN(w.f) && round4(r.F) != w.f) {
+			t.Fatalf("TwoWayANOVA(ToothGrowth) row %s = %+v; want df %g, SS %g, F %g", r.Source, r, w.df, w.ss, w.f)
+		}
+	}
+	if round4(table.Rows[2].PValue) != 0.0219 || table.Rows[1].PValue > 1e-15 {
+		t.Fatalf("TwoWayANOVA(ToothGrowth) p-values = %g, %g; want 0.0219, < 1e-15",
+			table.Rows[2].PValue, table.Rows[1].PValue)
+	}
+
+	// sequential sums of squares still partition the total when unbalanced
+	unbalanced, err := TwoWayANOVA(toothGrowth[5:], supp[5:], dose[5:])
+	if err != nil {
+		t.Fatalf("error calling TwoWayANOVA(ToothGrowth[5:]): %s", err)
+	}
+	var total float64
+	for _, r := range unbalanced.Rows {
+		total += r.SumSq
+	}
+	if round4(total) != 2666.1753 {
+		t.Fatalf("TwoWayANOVA(ToothGrowth[5:]) total SS = %f; want 2666.1753", total)
+	}
+	if _, err := TwoWayANOVA(toothGrowth[:30], supp[:30], dose[:30]); err == nil {
+		t.Fatalf("TwoWayANOVA(VC only) should raise error: need at least 2 levels of each factor")
+	}
+}
+
+func TestTukeyHSD(t *testing.T) {
+	comparisons, err := TukeyHSD(plantGrowth, plantGroups(), 0.95)
+	if err != nil {
+		t.Fatalf("error calling TukeyHSD(PlantGrowth): %s", err)
+	}
+	want := []TukeyComparison[string]{
+		{A: "ctrl", B: "trt1", Diff: -0.371, Lower: -1.0622, Upper: 0.3202, PValue: 0.3909},
+		{A: "ctrl", B: "trt2", Diff: 0.494, Lower: -0.1972, Upper: 1.1852, PValue: 0.198},
+		{A: "trt1", B: "trt2", Diff: 0.865, Lower: 0.1738, Upper: 1.5562, PValue: 0.012},
+	}
+	if len(comparisons) != len(want) {
+		t.Fatalf("TukeyHSD(PlantGrowth) gave %d comparisons; want %d", len(comparisons), len(want))
+	}
+	for i, w := range want {
+		c := comparisons[i]
+		if c.A != w.A || c.B != w.B || round4(c.Diff) != w.Diff || round4(c.Lower) != w.Lower ||
+			round4(c.Upper) != w.Upper || round4(c.PValue) != w.PValue {
+			t.Fatalf("TukeyHSD(PlantGrowth)[%d] = %v; want %v", i, c, w)
+		}
+	}
+}
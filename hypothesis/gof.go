@@ -0,0 +1,256 @@
+package hypothesis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dcooper46/go-ds-from-scratch/distributions"
+)
+
+// kolmogorovQ returns the probability that the Kolmogorov
+// distribution exceeds lambda
+func kolmogorovQ(lambda float64) float64 {
+	if lambda <= 0.0 {
+		return 1.0
+	}
+	if lambda < 1.18 {
+		// the alternating series converges slowly here, so use
+		// the theta function form of the complementary sum
+		y := math.Exp(-math.Pi * math.Pi / (8.0 * lambda * lambda))
+		var sum float64
+		for j := 1; j <= 7; j += 2 {
+			sum += math.Pow(y, float64(j*j))
+		}
+		return 1.0 - math.Sqrt(2.0*math.Pi)/lambda*sum
+	}
+	var sum float64
+	sign := 1.0
+	for j := 1; j <= 100; j++ {
+		term := sign * math.Exp(-2.0*float64(j*j)*lambda*lambda)
+		sum += term
+		if math.Abs(term) < 1e-16 {
+			break
+		}
+		sign = -sign
+	}
+	return math.Max(0.0, math.Min(1.0, 2.0*sum))
+}
+
+// ksPValue gives the asymptotic p-value of the statistic d
+// for an effective sample size n, with Stephens' correction
+func ksPValue(d, n float64) float64 {
+	sn := math.Sqrt(n)
+	return kolmogorovQ((sn + 0.12 + 0.11/sn) * d)
+}
+
+// KSTest runs the one-sample Kolmogorov-Smirnov test of whether x is drawn
+// from the continuous distribution with the given CDF.  The statistic D is
+// the largest distance between the empirical and hypothesized CDFs and
+// doubles as the effect size.  The p-value is asymptotic.
+func KSTest(x []float64, cdf func(float64) float64) (*Result, error) {
+	if len(x) == 0 {
+		return nil, fmt.Errorf("need at least 1 values, got 0")
+	}
+	s := append([]float64{}, x...)
+	sort.Float64s(s)
+	n := float64(len(s))
+	var d float64
+	for i, xi := range s {
+		f := cdf(xi)
+		d = math.Max(d, math.Max(float64(i+1)/n-f, f-float64(i)/n))
+	}
+	return &Result{
+		Method:     "One-sample Kolmogorov-Smirnov test",
+		symbol:     "D",
+		Statistic:  d,
+		PValue:     ksPValue(d, n),
+		EffectSize: d,
+	}, nil
+}
+
+// KSTest2 runs the two-sample Kolmogorov-Smirnov test of whether x and y
+// are drawn from the same continuous distribution.  The statistic D is
+// the largest distance between the two empirical CDFs and doubles as
+// the effect size.  The p-value is asymptotic.
+func KSTest2(x, y []float64) (*Result, error) {
+	if len(x) == 0 || len(y) == 0 {
+		return nil, fmt.Errorf("need at least 1 value in each sample, got %d and %d", len(x), len(y))
+	}
+	sx := append([]float64{}, x...)
+	sy := append([]float64{}, y...)
+	sort.Float64s(sx)
+	sort.Float64s(sy)
+	n1, n2 := float64(len(sx)), float64(len(sy))
+	var d float64
+	i, j := 0, 0
+	for i < len(sx) && j < len(sy) {
+		// step past every copy of the smallest remaining value
+		v := math.Min(sx[i], sy[j])
+		for i < len(sx) && sx[i] == v {
+			i++
+		}
+		for j < len(sy) && sy[j] == v {
+			j++
+		}
+		d = math.Max(d, math.Abs(float64(i)/n1-float64(j)/n2))
+	}
+	return &Result{
+		Method:     "Two-sample Kolmogorov-Smirnov test",
+		symbol:     "D",
+		Statistic:  d,
+		PValue:     ksPValue(d, n1*n2/(n1+n2)),
+		EffectSize: d,
+	}, nil
+}
+
+// ChiSquaredIndependence runs Pearson's chi-squared test of independence
+// between the row and column variables of a contingency table of counts.
+// No continuity correction is applied.  The effect size is Cramer's V.
+func ChiSquaredIndependence(table [][]float64) (*Result, error) {
+	nrow := len(table)
+	if nrow < 2 || len(table[0]) < 2 {
+		return nil, fmt.Errorf("need at least a 2x2 table, got %d rows", nrow)
+	}
+	ncol := len(table[0])
+	rowSums := make([]float64, nrow)
+	colSums := make([]float64, ncol)
+	var total float64
+	for i, row := range table {
+		if len(row) != ncol {
+			return nil, fmt.Errorf("ValueError: bad vector %v", row)
+		}
+		for j, v := range row {
+			if v < 0.0 {
+				return nil, fmt.Errorf("counts must be non-negative, got %f", v)
+			}
+			rowSums[i] += v
+			colSums[j] += v
+			total += v
+		}
+	}
+	var stat float64
+	for i, row := range table {
+		for j, v := range row {
+			expected := rowSums[i] * colSums[j] / total
+			if expected == 0.0 {
+				return nil, fmt.Errorf("row %d or column %d has no counts", i, j)
+			}
+			stat += (v - expected) * (v - expected) / expected
+		}
+	}
+	df := float64((nrow - 1) * (ncol - 1))
+	k := float64(min(nrow, ncol))
+	// upper tail of the chi-squared distribution, without the
+	// cancellation in 1 - CDF that rounds tiny p-values to 0
+	return &Result{
+		Method:     "Pearson's Chi-squared test",
+		symbol:     "X-squared",
+		Statistic:  stat,
+		DF:         df,
+		PValue:     distributions.RegIncGammaUpper(df/2.0, stat/2.0),
+		EffectSize: math.Sqrt(stat / (total * (k - 1.0))),
+	}, nil
+}
+
+// poly evaluates the polynomial with coefficients c in increasing order
+func poly(c []float64, x float64) float64 {
+	var p float64
+	for i := len(c) - 1; i >= 0; i-- {
+		p = p*x + c[i]
+	}
+	return p
+}
+
+// ShapiroWilk tests whether x is drawn from a normal distribution using
+// Royston's (1995) approximation to the Shapiro-Wilk W statistic and its
+// p-value, valid for 3 to 5000 values.  W is near 1 for normal data and
+// doubles as the effect size.
+func ShapiroWilk(x []float64) (*Result, error) {
+	n := len(x)
+	if n < 3 || n > 5000 {
+		return nil, fmt.Errorf("need between 3 and 5000 values, got %d", n)
+	}
+	s := append([]float64{}, x...)
+	sort.Float64s(s)
+	if s[n-1]-s[0] < 1e-19 {
+		return nil, fmt.Errorf("all values are identical")
+	}
+
+	// coefficients a[i] for the i-th smallest and largest values
+	half := n / 2
+	a := make([]float64, half)
+	an := float64(n)
+	if n == 3 {
+		a[0] = math.Sqrt(0.5)
+	} else {
+		m := make([]float64, half)
+		var summ2 float64
+		for i := range m {
+			m[i] = distributions.StandardNormal.Quantile((float64(i+1) - 0.375) / (an + 0.25))
+			summ2 += m[i] * m[i]
+		}
+		summ2 *= 2.0
+		ssumm2 := math.Sqrt(summ2)
+		rsn := 1.0 / math.Sqrt(an)
+		a1 := poly([]float64{0.0, 0.221157, -0.147981, -2.071190, 4.434685, -2.706056}, rsn) - m[0]/ssumm2
+		first := 1
+		var fac float64
+		if n > 5 {
+			first = 2
+			a2 := poly([]float64{0.0, 0.042981, -0.293762, -1.752461, 5.682633, -3.582633}, rsn) - m[1]/ssumm2
+			fac = math.Sqrt((summ2 - 2.0*m[0]*m[0] - 2.0*m[1]*m[1]) / (1.0 - 2.0*a1*a1 - 2.0*a2*a2))
+			a[1] = a2
+		} else {
+			fac = math.Sqrt((summ2 - 2.0*m[0]*m[0]) / (1.0 - 2.0*a1*a1))
+		}
+		a[0] = a1
+		for i := first; i < half; i++ {
+			a[i] = -m[i] / fac
+		}
+	}
+
+	var mean float64
+	for _, v := range s {
+		mean += v
+	}
+	mean /= an
+	var num, ss float64
+	for i, ai := range a {
+		num += ai * (s[n-1-i] - s[i])
+	}
+	for _, v := range s {
+		ss += (v - mean) * (v - mean)
+	}
+	w := math.Min(1.0, num*num/ss)
+
+	var p float64
+	switch {
+	case n == 3:
+		p = math.Max(0.0, 6.0/math.Pi*(math.Asin(math.Sqrt(w))-math.Pi/3.0))
+	case n <= 11:
+		y := math.Log(1.0 - w)
+		gamma := poly([]float64{-2.273, 0.459}, an)
+		if y >= gamma {
+			p = 1e-99
+			break
+		}
+		y = -math.Log(gamma - y)
+		mu := poly([]float64{0.544, -0.39978, 0.025054, -6.714e-4}, an)
+		sigma := math.Exp(poly([]float64{1.3822, -0.77857, 0.062767, -0.0020322}, an))
+		p = distributions.StandardNormal.CDF(-(y - mu) / sigma)
+	default:
+		y := math.Log(1.0 - w)
+		ln := math.Log(an)
+		mu := poly([]float64{-1.5861, -0.31082, -0.083751, 0.0038915}, ln)
+		sigma := math.Exp(poly([]float64{-0.4803, -0.082676, 0.0030302}, ln))
+		p = distributions.StandardNormal.CDF(-(y - mu) / sigma)
+	}
+	return &Result{
+		Method:     "Shapiro-Wilk normality test",
+		symbol:     "W",
+		Statistic:  w,
+		PValue:     p,
+		EffectSize: w,
+	}, nil
+}
@@ -0,0 +1,94 @@
+package hypothesis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dcooper46/go-ds-from-scratch/distributions"
+)
+
+func TestKSTest(t *testing.T) {
+	x := []float64{0.80, 0.83, 1.89, 1.04, 1.45, 1.38, 1.91, 1.64, 0.73, 1.46}
+	r, err := KSTest(x, distributions.Normal{Mu: 1.0, Sigma: 0.5}.CDF)
+	if err != nil {
+		t.Fatalf("error calling KSTest(x, N(1, 0.5)): %s", err)
+	}
+	if round4(r.Statistic) != 0.3764 || round4(r.PValue) != 0.0885 {
+		t.Fatalf("KSTest(x, N(1, 0.5)) = D %f, p %f; want 0.3764, 0.0885", r.Statistic, r.PValue)
+	}
+	if _, err := KSTest([]float64{}, distributions.StandardNormal.CDF); err == nil {
+		t.Fatalf("KSTest([], N(0, 1)) should raise error: need at least 1 values, got 0")
+	}
+}
+
+func TestKSTest2(t *testing.T) {
+	x := []float64{0.80, 0.83, 1.89, 1.04, 1.45, 1.38, 1.91, 1.64, 0.73, 1.46}
+	y := []float64{1.15, 0.88, 0.90, 0.74, 1.21}
+	r, err := KSTest2(x, y)
+	if err != nil {
+		t.Fatalf("error calling KSTest2(x, y): %s", err)
+	}
+	if r.Statistic != 0.6 || round4(r.PValue) != 0.1103 {
+		t.Fatalf("KSTest2(x, y) = D %f, p %f; want 0.6, 0.1103", r.Statistic, r.PValue)
+	}
+}
+
+func TestKolmogorovQ(t *testing.T) {
+	// both series agree where they meet
+	below, above := kolmogorovQ(1.18-1e-9), kolmogorovQ(1.18)
+	if math.Abs(below-above) > 1e-9 {
+		t.Fatalf("kolmogorovQ(1.18) = %g from below, %g from above; want equal", below, above)
+	}
+	if actual := round4(kolmogorovQ(1.3581)); actual != 0.05 {
+		t.Fatalf("kolmogorovQ(1.3581) = %f; want 0.05", actual)
+	}
+}
+
+func TestChiSquaredIndependence(t *testing.T) {
+	table := [][]float64{{762.0, 327.0, 468.0}, {484.0, 239.0, 477.0}}
+	r, err := ChiSquaredIndependence(table)
+	if err != nil {
+		t.Fatalf("error calling ChiSquaredIndependence(table): %s", err)
+	}
+	if round4(r.Statistic) != 30.0701 || r.DF != 2.0 || math.Round(r.PValue*1e10)/1e10 != 2.954e-07 {
+		t.Fatalf("ChiSquaredIndependence(table) = X2 %f, df %f, p %g; want 30.0701, 2, 2.954e-07",
+			r.Statistic, r.DF, r.PValue)
+	}
+	if round4(r.EffectSize) != 0.1044 {
+		t.Fatalf("ChiSquaredIndependence(table) Cramer's V = %f; want 0.1044", r.EffectSize)
+	}
+	// a p-value far below machine epsilon is not rounded to 0
+	strong, _ := ChiSquaredIndependence([][]float64{{100.0, 20.0}, {20.0, 100.0}})
+	if math.Abs(strong.PValue-5.2671e-25)/5.2671e-25 > 1e-4 {
+		t.Fatalf("ChiSquaredIndependence([[100 20] [20 100]]) p = %g; want 5.2671e-25", strong.PValue)
+	}
+	if _, err := ChiSquaredIndependence([][]float64{{1.0, 2.0}}); err == nil {
+		t.Fatalf("ChiSquaredIndependence([[1, 2]]) should raise error: need at least a 2x2 table")
+	}
+}
+
+func TestShapiroWilk(t *testing.T) {
+	cases := []struct {
+		x    []float64
+		w, p float64
+	}{
+		{[]float64{1.0, 2.0, 4.0}, 0.9643, 0.6369},
+		{[]float64{148, 154, 158, 160, 161, 162, 166, 170, 182, 195, 236}, 0.7888, 0.0067},
+		{[]float64{2.1, 3.4, 1.9, 5.6, 4.4, 3.8, 2.9, 4.1, 3.3, 2.5, 3.7, 4.8, 3.0, 2.2}, 0.9698, 0.8735},
+	}
+	for _, c := range cases {
+		r, err := ShapiroWilk(c.x)
+		if err != nil {
+			t.Fatalf("error calling ShapiroWilk(%v): %s", c.x, err)
+		}
+		if round4(r.Statistic) != c.w || round4(r.PValue) != c.p {
+			t.Fatalf("ShapiroWilk(%v) = W %f, p %f; want %f, %f", c.x, r.Statistic, r.PValue, c.w, c.p)
+		}
+	}
+	if _, err := ShapiroWilk([]float64{1.0, 2.0}); err == nil {
+		t.Fatalf("ShapiroWilk([1, 2]) should raise error: need between 3 and 5000 values")
+	}
+	if _, err := ShapiroWilk([]float64{1.0, 1.0, 1.0}); err == nil {
+		t.Fatalf("ShapiroWilk([1, 1, 1]) should raise error: all values are identical")
+	}
+}
@@ -0,0 +1,148 @@
+// Package hypothesis provides the classical significance tests from the
+// book's inference chapter: z-tests and t-tests on means, with p-values
+// and confidence intervals, an A/B test on proportions, rank based and
+// goodness-of-fit tests for data that is far from normal, and analysis
+// of variance with Tukey's post-hoc comparisons.
+package hypothesis
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Alternative is the alternative hypothesis a test is run against
+type Alternative int
+
+const (
+	// TwoSided tests whether the true value differs from the null value
+	TwoSided Alternative = iota
+	// Less tests whether the true value is below the null value
+	Less
+	// Greater tests whether the true value is above the null value
+	Greater
+)
+
+// String names the alternative the way R does
+func (a Alternative) String() string {
+	switch a {
+	case TwoSided:
+		return "two.sided"
+	case Less:
+		return "less"
+	case Greater:
+		return "greater"
+	}
+	return fmt.Sprintf("Alternative(%d)", int(a))
+}
+
+// Result holds the outcome of a significance test
+type Result struct {
+	// Method names the test that was run
+	Method string
+	// Statistic is the test statistic, such as z, t, U or D
+	Statistic float64
+	// DF is the degrees of freedom, zero when the null
+	// distribution has none
+	DF float64
+	// PValue is the probability of a statistic at least as extreme
+	// under the null hypothesis
+	PValue float64
+	// EffectSize measures how large the effect is independently of
+	// the sample size.  Each test documents the measure it reports.
+	EffectSize float64
+	// Estimate is the estimated mean, difference in means
+	// or difference in proportions
+	Estimate float64
+	// Lower and Upper bound the confidence interval for Estimate.
+	// One-sided alternatives give a one-sided interval.
+	Lower float64
+	Upper float64
+	// Level is the confidence level of the interval,
+	// zero for tests that do not give one
+	Level float64
+
+	// symbol labels the statistic when printing
+	symbol string
+}
+
+// String summarizes the result on a few lines
+func (r *Result) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s\n", r.Method)
+	fmt.Fprintf(&sb, "%s = %.4f, ", r.symbol, r.Statistic)
+	if r.DF > 0.0 {
+		fmt.Fprintf(&sb, "df = %.4g, ", r.DF)
+	}
+	fmt.Fprintf(&sb, "p-value = %.4g\n", r.PValue)
+	if r.Level > 0.0 {
+		fmt.Fprintf(&sb, "%g percent confidence interval: %.6f %.6f\n", 100.0*r.Level, r.Lower, r.Upper)
+		fmt.Fprintf(&sb, "estimate: %.6f\n", r.Estimate)
+	}
+	fmt.Fprintf(&sb, "effect size: %.6f", r.EffectSize)
+	return sb.String()
+}
+
+// Significant reports whether the null hypothesis is rejected at level alpha
+func (r *Result) Significant(alpha float64) bool {
+	return r.PValue < alpha
+}
+
+// symmetric is a distribution of the test statistic under the null
+// hypothesis that is symmetric about zero
+type symmetric interface {
+	CDF(x float64) float64
+	Quantile(p float64) float64
+}
+
+// checkLevel returns an error for a confidence level outside (0, 1)
+func checkLevel(level float64) error {
+	if !(level > 0.0 && level < 1.0) {
+		return fmt.Errorf("confidence level must be in (0, 1), got %f", level)
+	}
+	return nil
+}
+
+// checkAlternative returns an error for an unknown alternative
+func checkAlternative(alt Alternative) error {
+	if alt < TwoSided || alt > Greater {
+		return fmt.Errorf("unknown alternative: %d", alt)
+	}
+	return nil
+}
+
+// newResult finishes a test given its statistic, the null distribution
+// of the statistic, the estimate and its standard error
+func newResult(method, symbol string, stat, df float64, dist symmetric, alt Alternative, estimate, se, level float64) *Result {
+	r := &Result{
+		Method:    method,
+		symbol:    symbol,
+		Statistic: stat,
+		DF:        df,
+		Estimate:  estimate,
+		Level:     level,
+	}
+	r.PValue = pValue(stat, dist, alt)
+	switch alt {
+	case TwoSided:
+		q := dist.Quantile(1.0 - (1.0-level)/2.0)
+		r.Lower, r.Upper = estimate-q*se, estimate+q*se
+	case Less:
+		r.Lower, r.Upper = math.Inf(-1), estimate+dist.Quantile(level)*se
+	case Greater:
+		r.Lower, r.Upper = estimate-dist.Quantile(level)*se, math.Inf(1)
+	}
+	return r
+}
+
+// pValue returns the probability under dist of a statistic
+// at least as extreme as stat in the direction of alt
+func pValue(stat float64, dist symmetric, alt Alternative) float64 {
+	switch alt {
+	case Less:
+		return dist.CDF(stat)
+	case Greater:
+		return dist.CDF(-stat)
+	}
+	return math.Min(1.0, 2.0*dist.CDF(-math.Abs(stat)))
+}
@@ -0,0 +1,142 @@
+package hypothesis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dcooper46/go-ds-from-scratch/distributions"
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+// ranks returns the ranks of x starting at 1, averaging the ranks of
+// tied values, and the tie correction sum(t^3 - t) over tie groups
+func ranks(x []float64) (r []float64, ties float64) {
+	order := make([]int, len(x))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return x[order[i]] < x[order[j]] })
+	r = make([]float64, len(x))
+	for i := 0; i < len(order); {
+		j := i
+		for j < len(order) && x[order[j]] == x[order[i]] {
+			j++
+		}
+		// positions i..j-1 share the average of ranks i+1..j
+		avg := float64(i+j+1) / 2.0
+		for k := i; k < j; k++ {
+			r[order[k]] = avg
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+	return r, ties
+}
+
+// continuity shifts a normal approximation's deviation half a unit
+// towards zero in the direction of the alternative
+func continuity(dev float64, alt Alternative) float64 {
+	switch alt {
+	case Less:
+		return 0.5
+	case Greater:
+		return -0.5
+	}
+	if dev > 0.0 {
+		return -0.5
+	} else if dev < 0.0 {
+		return 0.5
+	}
+	return 0.0
+}
+
+// MannWhitneyU tests whether values of x tend to be larger or smaller than
+// values of y using the Mann-Whitney U (Wilcoxon rank-sum) statistic for x.
+// The p-value is from the normal approximation with tie and continuity
+// corrections.  The effect size is the rank-biserial correlation, positive
+// when x tends to be larger.
+func MannWhitneyU(x, y []float64, alt Alternative) (*Result, error) {
+	if err := checkAlternative(alt); err != nil {
+		return nil, err
+	}
+	if len(x) == 0 || len(y) == 0 {
+		return nil, fmt.Errorf("need at least 1 value in each sample, got %d and %d", len(x), len(y))
+	}
+	n1, n2 := float64(len(x)), float64(len(y))
+	combined := append(append([]float64{}, x...), y...)
+	r, ties := ranks(combined)
+	u := utils.VectorSum(r[:len(x)]) - n1*(n1+1.0)/2.0
+
+	n := n1 + n2
+	mu := n1 * n2 / 2.0
+	sigma := math.Sqrt(n1 * n2 / 12.0 * ((n + 1.0) - ties/(n*(n-1.0))))
+	if sigma == 0.0 {
+		return nil, fmt.Errorf("all values are tied")
+	}
+	dev := u - mu
+	z := (dev + continuity(dev, alt)) / sigma
+	return &Result{
+		Method:     "Mann-Whitney U test",
+		symbol:     "U",
+		Statistic:  u,
+		PValue:     pValue(z, distributions.StandardNormal, alt),
+		EffectSize: 2.0*u/(n1*n2) - 1.0,
+	}, nil
+}
+
+// WilcoxonSignedRank tests whether x is symmetric about mu0 using the sum
+// of the ranks of the positive differences x - mu0.  Zero differences are
+// dropped.  The p-value is from the normal approximation with tie and
+// continuity corrections.  The effect size is the matched-pairs
+// rank-biserial correlation.
+func WilcoxonSignedRank(x []float64, mu0 float64, alt Alternative) (*Result, error) {
+	if err := checkAlternative(alt); err != nil {
+		return nil, err
+	}
+	var diffs []float64
+	for _, xi := range x {
+		if d := xi - mu0; d != 0.0 {
+			diffs = append(diffs, d)
+		}
+	}
+	if len(diffs) == 0 {
+		return nil, fmt.Errorf("need at least 1 nonzero difference, got 0")
+	}
+	abs := make([]float64, len(diffs))
+	for i, d := range diffs {
+		abs[i] = math.Abs(d)
+	}
+	r, ties := ranks(abs)
+	var v float64
+	for i, d := range diffs {
+		if d > 0.0 {
+			v += r[i]
+		}
+	}
+
+	n := float64(len(diffs))
+	total := n * (n + 1.0) / 2.0
+	mu := total / 2.0
+	sigma := math.Sqrt(n*(n+1.0)*(2.0*n+1.0)/24.0 - ties/48.0)
+	dev := v - mu
+	z := (dev + continuity(dev, alt)) / sigma
+	return &Result{
+		Method:     "Wilcoxon signed rank test",
+		symbol:     "V",
+		Statistic:  v,
+		PValue:     pValue(z, distributions.StandardNormal, alt),
+		EffectSize: (2.0*v - total) / total,
+	}, nil
+}
+
+// PairedWilcoxon runs the Wilcoxon signed-rank test
+// on the paired differences x - y
+func PairedWilcoxon(x, y []float64, alt Alternative) (*Result, error) {
+	diffs, err := utils.VectorSub(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return WilcoxonSignedRank(diffs, 0.0, alt)
+}
@@ -0,0 +1,53 @@
+package hypothesis
+
+import (
+	"testing"
+
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+func TestRanks(t *testing.T) {
+	r, ties := ranks([]float64{3.0, 1.0, 4.0, 1.0, 5.0})
+	if !utils.VectorsEqual(r, []float64{3.0, 1.5, 4.0, 1.5, 5.0}) || ties != 6.0 {
+		t.Fatalf("ranks([3, 1, 4, 1, 5]) = %v, %f; want [3 1.5 4 1.5 5], 6", r, ties)
+	}
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	x := []float64{0.80, 0.83, 1.89, 1.04, 1.45, 1.38, 1.91, 1.64, 0.73, 1.46}
+	y := []float64{1.15, 0.88, 0.90, 0.74, 1.21}
+	r, err := MannWhitneyU(x, y, Greater)
+	if err != nil {
+		t.Fatalf("error calling MannWhitneyU(x, y, Greater): %s", err)
+	}
+	if r.Statistic != 35.0 || round4(r.PValue) != 0.1223 || round4(r.EffectSize) != 0.4 {
+		t.Fatalf("MannWhitneyU(x, y, Greater) = U %f, p %f, r %f; want 35, 0.1223, 0.4",
+			r.Statistic, r.PValue, r.EffectSize)
+	}
+	r, err = MannWhitneyU(x, y, TwoSided)
+	if err != nil {
+		t.Fatalf("error calling MannWhitneyU(x, y): %s", err)
+	}
+	if round4(r.PValue) != 0.2446 {
+		t.Fatalf("MannWhitneyU(x, y) p-value = %f; want 0.2446", r.PValue)
+	}
+	if _, err := MannWhitneyU(x, []float64{}, TwoSided); err == nil {
+		t.Fatalf("MannWhitneyU(x, []) should raise error: need at least 1 value in each sample")
+	}
+}
+
+func TestPairedWilcoxon(t *testing.T) {
+	x := []float64{1.83, 0.50, 1.62, 2.48, 1.68, 1.88, 1.55, 3.06, 1.30}
+	y := []float64{0.878, 0.647, 0.598, 2.05, 1.06, 1.29, 1.06, 3.14, 1.29}
+	r, err := PairedWilcoxon(x, y, Greater)
+	if err != nil {
+		t.Fatalf("error calling PairedWilcoxon(x, y, Greater): %s", err)
+	}
+	if r.Statistic != 40.0 || round4(r.PValue) != 0.022 || round4(r.EffectSize) != 0.7778 {
+		t.Fatalf("PairedWilcoxon(x, y, Greater) = V %f, p %f, r %f; want 40, 0.022, 0.7778",
+			r.Statistic, r.PValue, r.EffectSize)
+	}
+	if _, err := WilcoxonSignedRank([]float64{2.0, 2.0}, 2.0, TwoSided); err == nil {
+		t.Fatalf("WilcoxonSignedRank([2, 2], 2) should raise error: need at least 1 nonzero difference")
+	}
+}
@@ -0,0 +1,40 @@
+package hypothesis
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dcooper46/go-ds-from-scratch/distributions"
+)
+
+// ABTest compares the success rates of two variants with a two-proportion
+// z-test.  The statistic uses the pooled rate under the null hypothesis of
+// equal rates, and the interval for rate(A) - rate(B) uses unpooled
+// standard errors.  The effect size is Cohen's h.
+func ABTest(successesA, trialsA, successesB, trialsB int, alt Alternative, level float64) (*Result, error) {
+	if err := checkAlternative(alt); err != nil {
+		return nil, err
+	}
+	if err := checkLevel(level); err != nil {
+		return nil, err
+	}
+	if trialsA <= 0 || trialsB <= 0 {
+		return nil, fmt.Errorf("need at least 1 trial per variant, got %d and %d", trialsA, trialsB)
+	}
+	if successesA < 0 || successesA > trialsA || successesB < 0 || successesB > trialsB {
+		return nil, fmt.Errorf("successes must be between 0 and trials, got %d/%d and %d/%d",
+			successesA, trialsA, successesB, trialsB)
+	}
+	na, nb := float64(trialsA), float64(trialsB)
+	pa, pb := float64(successesA)/na, float64(successesB)/nb
+	pooled := float64(successesA+successesB) / (na + nb)
+	sePooled := math.Sqrt(pooled * (1.0 - pooled) * (1.0/na + 1.0/nb))
+	if sePooled == 0.0 {
+		return nil, fmt.Errorf("success rate is 0 or 1 in both variants")
+	}
+	se := math.Sqrt(pa*(1.0-pa)/na + pb*(1.0-pb)/nb)
+	diff := pa - pb
+	r := newResult("Two Proportion z-test", "z", diff/sePooled, 0.0, distributions.StandardNormal, alt, diff, se, level)
+	r.EffectSize = 2.0*math.Asin(math.Sqrt(pa)) - 2.0*math.Asin(math.Sqrt(pb))
+	return r, nil
+}
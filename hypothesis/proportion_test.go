@@ -0,0 +1,23 @@
+package hypothesis
+
+import "testing"
+
+func TestABTest(t *testing.T) {
+	r, err := ABTest(200, 1000, 250, 1000, TwoSided, 0.95)
+	if err != nil {
+		t.Fatalf("error calling ABTest(200/1000, 250/1000): %s", err)
+	}
+	if round4(r.Statistic) != -2.6774 || round4(r.PValue) != 0.0074 || round4(r.Estimate) != -0.05 {
+		t.Fatalf("ABTest(200/1000, 250/1000) = z %f, p %f, estimate %f; want -2.6774, 0.0074, -0.05",
+			r.Statistic, r.PValue, r.Estimate)
+	}
+	if round4(r.Lower) != -0.0865 || round4(r.Upper) != -0.0135 {
+		t.Fatalf("ABTest(200/1000, 250/1000) interval = [%f, %f]; want [-0.0865, -0.0135]", r.Lower, r.Upper)
+	}
+	if _, err := ABTest(20, 10, 5, 10, TwoSided, 0.95); err == nil {
+		t.Fatalf("ABTest(20/10, 5/10) should raise error: successes must be between 0 and trials")
+	}
+	if _, err := ABTest(0, 10, 0, 10, TwoSided, 0.95); err == nil {
+		t.Fatalf("ABTest(0/10, 0/10) should raise error: success rate is 0 or 1 in both variants")
+	}
+}
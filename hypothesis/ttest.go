@@ -0,0 +1,107 @@
+package hypothesis
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dcooper46/go-ds-from-scratch/distributions"
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+// meanAndSE returns the mean of x and the standard error of the mean
+func meanAndSE(x []float64) (mu, se float64, err error) {
+	mu, err = utils.Mean(x)
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	std, err := utils.StandardDeviation(x)
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	return mu, std / math.Sqrt(float64(len(x))), nil
+}
+
+// OneSampleTTest tests whether the mean of x equals mu0
+// when the population standard deviation is unknown.
+// The effect size is Cohen's d.
+func OneSampleTTest(x []float64, mu0 float64, alt Alternative, level float64) (*Result, error) {
+	if err := checkAlternative(alt); err != nil {
+		return nil, err
+	}
+	if err := checkLevel(level); err != nil {
+		return nil, err
+	}
+	mu, se, err := meanAndSE(x)
+	if err != nil {
+		return nil, err
+	}
+	if se == 0.0 {
+		return nil, fmt.Errorf("data are essentially constant")
+	}
+	df := float64(len(x) - 1)
+	t := (mu - mu0) / se
+	r := newResult("One Sample t-test", "t", t, df, distributions.StudentT{Nu: df}, alt, mu, se, level)
+	r.EffectSize = (mu - mu0) / (se * math.Sqrt(float64(len(x))))
+	return r, nil
+}
+
+// WelchTTest tests whether the means of x and y are equal without
+// assuming equal variances, using the Welch-Satterthwaite degrees
+// of freedom.  The estimate is the difference mean(x) - mean(y)
+// and the effect size is Cohen's d using the average of the variances.
+func WelchTTest(x, y []float64, alt Alternative, level float64) (*Result, error) {
+	if err := checkAlternative(alt); err != nil {
+		return nil, err
+	}
+	if err := checkLevel(level); err != nil {
+		return nil, err
+	}
+	mux, sex, err := meanAndSE(x)
+	if err != nil {
+		return nil, err
+	}
+	muy, sey, err := meanAndSE(y)
+	if err != nil {
+		return nil, err
+	}
+	vx, vy := sex*sex, sey*sey
+	se := math.Sqrt(vx + vy)
+	if se == 0.0 {
+		return nil, fmt.Errorf("data are essentially constant")
+	}
+	df := (vx + vy) * (vx + vy) /
+		(vx*vx/float64(len(x)-1) + vy*vy/float64(len(y)-1))
+	diff := mux - muy
+	r := newResult("Welch Two Sample t-test", "t", diff/se, df, distributions.StudentT{Nu: df}, alt, diff, se, level)
+	r.EffectSize = diff / math.Sqrt((vx*float64(len(x))+vy*float64(len(y)))/2.0)
+	return r, nil
+}
+
+// PairedTTest tests whether the mean of the paired differences x - y is zero.
+// The effect size is Cohen's d of the differences.
+func PairedTTest(x, y []float64, alt Alternative, level float64) (*Result, error) {
+	diffs, err := utils.VectorSub(x, y)
+	if err != nil {
+		return nil, err
+	}
+	r, err := OneSampleTTest(diffs, 0.0, alt, level)
+	if err != nil {
+		return nil, err
+	}
+	r.Method = "Paired t-test"
+	return r, nil
+}
+
+// ConfidenceInterval returns the t interval for the mean of x
+// at the given confidence level
+func ConfidenceInterval(x []float64, level float64) (lower, upper float64, err error) {
+	if err := checkLevel(level); err != nil {
+		return 0.0, 0.0, err
+	}
+	mu, se, err := meanAndSE(x)
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	q := distributions.StudentT{Nu: float64(len(x) - 1)}.Quantile(1.0 - (1.0-level)/2.0)
+	return mu - q*se, mu + q*se, nil
+}
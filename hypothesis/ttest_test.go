@@ -0,0 +1,95 @@
+package hypothesis
+
+import (
+	"math"
+	"testing"
+)
+
+// sleep is R's sleep data set, extra hours of sleep under two drugs
+var sleep1 = []float64{0.7, -1.6, -0.2, -1.2, -0.1, 3.4, 3.7, 0.8, 0.0, 2.0}
+var sleep2 = []float64{1.9, 0.8, 1.1, 0.1, -0.1, 4.4, 5.5, 1.6, 4.6, 3.4}
+
+func round4(x float64) float64 {
+	return math.Round(x*10000) / 10000
+}
+
+func TestOneSampleTTest(t *testing.T) {
+	r, err := OneSampleTTest(sleep1, 0.0, TwoSided, 0.95)
+	if err != nil {
+		t.Fatalf("error calling OneSampleTTest(sleep1, 0): %s", err)
+	}
+	if round4(r.Statistic) != 1.3257 || r.DF != 9.0 || round4(r.PValue) != 0.2176 {
+		t.Fatalf("OneSampleTTest(sleep1, 0) = t %f, df %f, p %f; want 1.3257, 9, 0.2176",
+			r.Statistic, r.DF, r.PValue)
+	}
+	if round4(r.Lower) != -0.5298 || round4(r.Upper) != 2.0298 {
+		t.Fatalf("OneSampleTTest(sleep1, 0) interval = [%f, %f]; want [-0.5298, 2.0298]", r.Lower, r.Upper)
+	}
+	if round4(r.EffectSize) != 0.4192 {
+		t.Fatalf("OneSampleTTest(sleep1, 0) Cohen's d = %f; want 0.4192", r.EffectSize)
+	}
+
+	r, err = OneSampleTTest(sleep1, 0.0, Greater, 0.95)
+	if err != nil {
+		t.Fatalf("error calling OneSampleTTest(sleep1, 0, Greater): %s", err)
+	}
+	if round4(r.PValue) != 0.1088 || round4(r.Lower) != -0.2871 || !math.IsInf(r.Upper, 1) {
+		t.Fatalf("OneSampleTTest(sleep1, 0, Greater) = p %f, [%f, %f]; want 0.1088, [-0.2871, +Inf]",
+			r.PValue, r.Lower, r.Upper)
+	}
+
+	if _, err := OneSampleTTest([]float64{1.0, 1.0, 1.0}, 0.0, TwoSided, 0.95); err == nil {
+		t.Fatalf("OneSampleTTest([1, 1, 1], 0) should raise error: data are essentially constant")
+	}
+	if _, err := OneSampleTTest(sleep1, 0.0, TwoSided, 95.0); err == nil {
+		t.Fatalf("OneSampleTTest(sleep1, 0, level 95) should raise error: confidence level must be in (0, 1)")
+	}
+}
+
+func TestWelchTTest(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	y := []float64{7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	r, err := WelchTTest(x, y, TwoSided, 0.95)
+	if err != nil {
+		t.Fatalf("error calling WelchTTest(1:10, 7:20): %s", err)
+	}
+	if round4(r.Statistic) != -5.4349 || round4(r.DF) != 21.9822 {
+		t.Fatalf("WelchTTest(1:10, 7:20) = t %f, df %f; want -5.4349, 21.9822", r.Statistic, r.DF)
+	}
+	if math.Round(r.PValue*1e8)/1e8 != 1.855e-05 {
+		t.Fatalf("WelchTTest(1:10, 7:20) p-value = %g; want 1.855e-05", r.PValue)
+	}
+	if round4(r.Lower) != -11.0528 || round4(r.Upper) != -4.9472 {
+		t.Fatalf("WelchTTest(1:10, 7:20) interval = [%f, %f]; want [-11.0528, -4.9472]", r.Lower, r.Upper)
+	}
+	if _, err := WelchTTest(x, []float64{1.0}, TwoSided, 0.95); err == nil {
+		t.Fatalf("WelchTTest(1:10, [1]) should raise error: need at least 2 values, got 1")
+	}
+}
+
+func TestPairedTTest(t *testing.T) {
+	r, err := PairedTTest(sleep1, sleep2, TwoSided, 0.95)
+	if err != nil {
+		t.Fatalf("error calling PairedTTest(sleep1, sleep2): %s", err)
+	}
+	if round4(r.Statistic) != -4.0621 || round4(r.PValue) != 0.0028 || r.Estimate != -1.58 {
+		t.Fatalf("PairedTTest(sleep1, sleep2) = t %f, p %f, estimate %f; want -4.0621, 0.0028, -1.58",
+			r.Statistic, r.PValue, r.Estimate)
+	}
+	if round4(r.Lower) != -2.4599 || round4(r.Upper) != -0.7001 {
+		t.Fatalf("PairedTTest(sleep1, sleep2) interval = [%f, %f]; want [-2.4599, -0.7001]", r.Lower, r.Upper)
+	}
+	if _, err := PairedTTest(sleep1, sleep2[1:], TwoSided, 0.95); err == nil {
+		t.Fatalf("PairedTTest(sleep1, sleep2[1:]) should raise error: vectors are of unequal size")
+	}
+}
+
+func TestConfidenceInterval(t *testing.T) {
+	lower, upper, err := ConfidenceInterval(sleep1, 0.95)
+	if err != nil {
+		t.Fatalf("error calling ConfidenceInterval(sleep1, 0.95): %s", err)
+	}
+	if round4(lower) != -0.5298 || round4(upper) != 2.0298 {
+		t.Fatalf("ConfidenceInterval(sleep1, 0.95) = [%f, %f]; want [-0.5298, 2.0298]", lower, upper)
+	}
+}
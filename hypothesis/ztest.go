@@ -0,0 +1,62 @@
+package hypothesis
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dcooper46/go-ds-from-scratch/distributions"
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+// OneSampleZTest tests whether the mean of x equals mu0 when the
+// population standard deviation sigma is known.
+// The effect size is Cohen's d.
+func OneSampleZTest(x []float64, mu0, sigma float64, alt Alternative, level float64) (*Result, error) {
+	if err := checkAlternative(alt); err != nil {
+		return nil, err
+	}
+	if err := checkLevel(level); err != nil {
+		return nil, err
+	}
+	if sigma <= 0.0 {
+		return nil, fmt.Errorf("standard deviation must be positive, got %f", sigma)
+	}
+	mu, err := utils.Mean(x)
+	if err != nil {
+		return nil, err
+	}
+	se := sigma / math.Sqrt(float64(len(x)))
+	z := (mu - mu0) / se
+	r := newResult("One Sample z-test", "z", z, 0.0, distributions.StandardNormal, alt, mu, se, level)
+	r.EffectSize = (mu - mu0) / sigma
+	return r, nil
+}
+
+// TwoSampleZTest tests whether the means of x and y are equal when
+// the population standard deviations sigmaX and sigmaY are known.
+// The estimate is the difference mean(x) - mean(y)
+// and the effect size is Cohen's d.
+func TwoSampleZTest(x, y []float64, sigmaX, sigmaY float64, alt Alternative, level float64) (*Result, error) {
+	if err := checkAlternative(alt); err != nil {
+		return nil, err
+	}
+	if err := checkLevel(level); err != nil {
+		return nil, err
+	}
+	if sigmaX <= 0.0 || sigmaY <= 0.0 {
+		return nil, fmt.Errorf("standard deviations must be positive, got %f and %f", sigmaX, sigmaY)
+	}
+	mux, err := utils.Mean(x)
+	if err != nil {
+		return nil, err
+	}
+	muy, err := utils.Mean(y)
+	if err != nil {
+		return nil, err
+	}
+	se := math.Sqrt(sigmaX*sigmaX/float64(len(x)) + sigmaY*sigmaY/float64(len(y)))
+	diff := mux - muy
+	r := newResult("Two Sample z-test", "z", diff/se, 0.0, distributions.StandardNormal, alt, diff, se, level)
+	r.EffectSize = diff / math.Sqrt((sigmaX*sigmaX+sigmaY*sigmaY)/2.0)
+	return r, nil
+}
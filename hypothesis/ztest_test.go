@@ -0,0 +1,38 @@
+package hypothesis
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOneSampleZTest(t *testing.T) {
+	r, err := OneSampleZTest(sleep1, 0.0, 2.0, TwoSided, 0.95)
+	if err != nil {
+		t.Fatalf("error calling OneSampleZTest(sleep1, 0, 2): %s", err)
+	}
+	if round4(r.Statistic) != 1.1859 || round4(r.PValue) != 0.2357 || r.DF != 0.0 {
+		t.Fatalf("OneSampleZTest(sleep1, 0, 2) = z %f, p %f; want 1.1859, 0.2357", r.Statistic, r.PValue)
+	}
+	if round4(r.Lower) != -0.4896 || round4(r.Upper) != 1.9896 {
+		t.Fatalf("OneSampleZTest(sleep1, 0, 2) interval = [%f, %f]; want [-0.4896, 1.9896]", r.Lower, r.Upper)
+	}
+	if _, err := OneSampleZTest(sleep1, 0.0, 0.0, TwoSided, 0.95); err == nil {
+		t.Fatalf("OneSampleZTest(sleep1, 0, 0) should raise error: standard deviation must be positive")
+	}
+}
+
+func TestTwoSampleZTest(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	y := []float64{7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	r, err := TwoSampleZTest(x, y, 3.0, 4.0, Less, 0.95)
+	if err != nil {
+		t.Fatalf("error calling TwoSampleZTest(1:10, 7:20, 3, 4, Less): %s", err)
+	}
+	if round4(r.Statistic) != -5.5972 || round4(r.Upper) != -5.649 || !math.IsInf(r.Lower, -1) {
+		t.Fatalf("TwoSampleZTest(1:10, 7:20, 3, 4, Less) = z %f, [%f, %f]; want -5.5972, [-Inf, -5.649]",
+			r.Statistic, r.Lower, r.Upper)
+	}
+	if !r.Significant(0.05) {
+		t.Fatalf("TwoSampleZTest(1:10, 7:20, 3, 4, Less) p-value = %g; want significant at 0.05", r.PValue)
+	}
+}
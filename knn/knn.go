@@ -4,8 +4,11 @@ package main
 // Not generalized for different data types, but basic float
 
 import (
+	"log"
 	"math"
 	"sort"
+
+	"github.com/dcooper46/go-ds-from-scratch/utils"
 )
 
 // LabeledPoint is a structure representing a row from a data source for KNN
@@ -84,3 +87,34 @@ func KnnClassify(k int, points []LabeledPoint, point []float64) LabeledPoint {
 
 	return classifiedPoint
 }
+
+// SparseLabeledPoint is a LabeledPoint whose features are mostly zero,
+// such as the word counts of a document
+type SparseLabeledPoint struct {
+	point *utils.SparseVector
+	label string
+}
+
+// KnnClassifySparse returns the label for a given sparse point from its
+// nearest neighbors, measuring distance over nonzero features only
+func KnnClassifySparse(k int, points []SparseLabeledPoint, point *utils.SparseVector) SparseLabeledPoint {
+	distances := make([]float64, len(points))
+	order := make([]int, len(points))
+	for i, lpoint := range points {
+		d, err := lpoint.point.Distance(point)
+		if err != nil {
+			log.Fatalf("error computing distance: %e", err)
+		}
+		distances[i], order[i] = d, i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return distances[order[i]] < distances[order[j]]
+	})
+
+	sortedLabels := make([]string, len(order))
+	for i, idx := range order {
+		sortedLabels[i] = points[idx].label
+	}
+
+	return SparseLabeledPoint{point: point, label: MajorityVote(sortedLabels[:k])}
+}
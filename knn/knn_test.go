@@ -0,0 +1,68 @@
+package main
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+func TestSplitDataReproducible(t *testing.T) {
+	points := func() []LabeledPoint {
+		data := make([]LabeledPoint, 50)
+		for i := range data {
+			data[i] = LabeledPoint{point: []float64{float64(i)}, label: "a"}
+		}
+		return data
+	}
+	trainA, testA := splitData(points(), 0.3, rand.New(rand.NewSource(42)))
+	trainB, testB := splitData(points(), 0.3, rand.New(rand.NewSource(42)))
+	if len(trainA)+len(testA) != 50 || len(trainA) != len(trainB) || len(testA) != len(testB) {
+		t.Fatalf("splitData with seed 42 sizes = %d/%d, then %d/%d; want equal and covering 50 points",
+			len(trainA), len(testA), len(trainB), len(testB))
+	}
+	for i := range testA {
+		if testA[i].point[0] != testB[i].point[0] {
+			t.Fatalf("splitData with seed 42 test point %d = %v, then %v; want equal", i, testA[i].point, testB[i].point)
+		}
+	}
+}
+
+func TestKnnClassifySparse(t *testing.T) {
+	// word counts of short documents over a shared vocabulary
+	vocab := map[string]int{}
+	bagOfWords := func(doc string) *utils.SparseVector {
+		counts := map[int]float64{}
+		for _, word := range strings.Fields(doc) {
+			if _, ok := vocab[word]; !ok {
+				vocab[word] = len(vocab)
+			}
+			counts[vocab[word]]++
+		}
+		var indices []int
+		var values []float64
+		for j, c := range counts {
+			indices, values = append(indices, j), append(values, c)
+		}
+		v, err := utils.NewSparseVector(100, indices, values)
+		if err != nil {
+			t.Fatalf("error calling NewSparseVector(100, indices, values): %s", err)
+		}
+		return v
+	}
+	var points []SparseLabeledPoint
+	for _, doc := range []struct{ text, label string }{
+		{"win free money now", "spam"}, {"free money free prize", "spam"}, {"click to win money", "spam"},
+		{"team meeting at noon", "ham"}, {"project meeting notes", "ham"}, {"lunch with the team", "ham"},
+	} {
+		points = append(points, SparseLabeledPoint{point: bagOfWords(doc.text), label: doc.label})
+	}
+	for _, test := range []struct{ text, want string }{
+		{"free money", "spam"}, {"team meeting", "ham"},
+	} {
+		if actual := KnnClassifySparse(3, points, bagOfWords(test.text)); actual.label != test.want {
+			t.Fatalf("KnnClassifySparse(3, points, %q) = %q; want %q", test.text, actual.label, test.want)
+		}
+	}
+}
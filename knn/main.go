@@ -8,6 +8,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+
+	"github.com/dcooper46/go-ds-from-scratch/utils"
 )
 
 func checkError(err error) {
@@ -16,10 +18,11 @@ func checkError(err error) {
 	}
 }
 
-func splitData(data []LabeledPoint, testPercent float64) (train, test []LabeledPoint) {
-	rand.Shuffle(len(data), func(i, j int) { data[i], data[j] = data[j], data[i] })
+// splitData shuffles the data and splits it for train/test, drawing from r
+func splitData(data []LabeledPoint, testPercent float64, r *rand.Rand) (train, test []LabeledPoint) {
+	r.Shuffle(len(data), func(i, j int) { data[i], data[j] = data[j], data[i] })
 	for _, point := range data {
-		if rand.Float64() < testPercent {
+		if r.Float64() < testPercent {
 			test = append(test, point)
 		} else {
 			train = append(train, point)
@@ -67,7 +70,7 @@ func main() {
 	irisData := readIris()
 
 	// split data for train/test
-	train, test := splitData(irisData, 0.45)
+	train, test := splitData(irisData, 0.45, rand.New(rand.NewSource(42)))
 
 	fmt.Printf("raw: %d, train: %d, test: %d\n", len(irisData), len(train), len(test))
 
@@ -80,13 +83,10 @@ func main() {
 	}
 
 	// print confusion matrix and some metrics
-	var correct int
-	for i, predicted := range predictions {
-		if predicted == truth[i] {
-			correct++
-		}
-	}
 	fmt.Printf("truth: %s...\n", strings.Join(truth[:5], ","))
 	fmt.Printf("preds: %s...\n", strings.Join(predictions[:5], ","))
-	fmt.Printf("accuracy: %f\n", float64(correct)/float64(len(predictions)))
+	confMat, err := utils.NewConfusionMatrix(truth, predictions)
+	checkError(err)
+	fmt.Println(confMat)
+	fmt.Println(confMat.Report())
 }
@@ -4,6 +4,7 @@ import (
 	"log"
 	"math"
 	"math/rand"
+	"slices"
 
 	"github.com/dcooper46/go-ds-from-scratch/utils"
 )
@@ -79,11 +80,12 @@ func LogisticLogGradient(x [][]float64, y, beta []float64) []float64 {
 }
 
 // EstimateBeta uses stochastic gradient decent to find
-// coefficents that minimize the squared loss
-func EstimateBeta(x [][]float64, y []float64) []float64 {
+// coefficents that minimize the squared loss, starting
+// from coefficients drawn from r
+func EstimateBeta(x [][]float64, y []float64, r *rand.Rand) []float64 {
 	betaInit := make([]float64, len(x[0]))
 	for i := range betaInit {
-		betaInit[i] = rand.Float64()
+		betaInit[i] = r.Float64()
 	}
 	return utils.StochasticGradientAscent(
 		LogisticLogLikelihoodX,
@@ -95,3 +97,74 @@ func EstimateBeta(x [][]float64, y []float64) []float64 {
 		10,
 	)
 }
+
+// SparseLogisticLogLikelihood returns the log-likelihood of the logistic
+// function for data whose records are the rows of a sparse matrix, such
+// as word counts, touching only the nonzero features of each record
+func SparseLogisticLogLikelihood(x *utils.CSR, y []float64, beta []float64) (logLikelihood float64) {
+	nrow, _ := x.Shape()
+	for i := 0; i < nrow; i++ {
+		dot, err := x.Row(i).DotDense(beta)
+		if err != nil {
+			log.Fatalf("error running dot product: %e", err)
+		}
+		if y[i] == 1.0 {
+			logLikelihood += math.Log(Logistic(dot))
+		} else {
+			logLikelihood += math.Log(1.0 - Logistic(dot))
+		}
+	}
+	return
+}
+
+// SparseLogisticLogGradient returns the gradient of the logistic log
+// likelihood for data whose records are the rows of a sparse matrix
+func SparseLogisticLogGradient(x *utils.CSR, y, beta []float64) []float64 {
+	nrow, _ := x.Shape()
+	grad := make([]float64, len(beta))
+	for i := 0; i < nrow; i++ {
+		row := x.Row(i)
+		dot, err := row.DotDense(beta)
+		if err != nil {
+			log.Fatalf("error running dot product: %e", err)
+		}
+		row.AddScaledTo(grad, y[i]-Logistic(dot))
+	}
+	return grad
+}
+
+// EstimateBetaSparse uses stochastic gradient ascent to find the
+// coefficients that maximize the likelihood of sparse records, such as
+// bag-of-words rows, starting from coefficients drawn from r.  Each update
+// only changes the coefficients of the features present in the record.
+// It stops after 10 passes without improvement, or after 1000 passes
+// since separable data never stops improving.
+func EstimateBetaSparse(x *utils.CSR, y []float64, r *rand.Rand) []float64 {
+	nrow, ncol := x.Shape()
+	beta := make([]float64, ncol)
+	for i := range beta {
+		beta[i] = r.Float64()
+	}
+	alpha0 := 0.1
+	alpha := alpha0
+	best := slices.Clone(beta)
+	bestValue := math.Inf(-1)
+	for pass, noBetter := 0, 0; pass < 1000 && noBetter < 10; pass++ {
+		value := SparseLogisticLogLikelihood(x, y, beta)
+		if value > bestValue+1e-9 {
+			copy(best, beta)
+			bestValue = value
+			noBetter = 0
+			alpha = alpha0
+		} else {
+			noBetter++
+			alpha *= 0.9
+		}
+		for i := 0; i < nrow; i++ {
+			row := x.Row(i)
+			dot, _ := row.DotDense(beta)
+			row.AddScaledTo(beta, alpha*(y[i]-Logistic(dot)))
+		}
+	}
+	return best
+}
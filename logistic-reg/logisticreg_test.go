@@ -0,0 +1,91 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+func TestEstimateBetaReproducible(t *testing.T) {
+	normX, err := utils.Normalize(x)
+	if err != nil {
+		t.Fatalf("error normalizing data: %s", err)
+	}
+	a := EstimateBeta(normX, y, rand.New(rand.NewSource(42)))
+	b := EstimateBeta(normX, y, rand.New(rand.NewSource(42)))
+	if !slices.Equal(a, b) {
+		t.Fatalf("EstimateBeta with seed 42 = %v, then %v; want equal", a, b)
+	}
+}
+
+// bagOfWords turns each document into a row of word counts over the
+// vocabulary, with a first column of ones for the intercept
+func bagOfWords(docs []string, vocab map[string]int) *utils.CSR {
+	var rows, cols []int
+	var values []float64
+	for i, doc := range docs {
+		rows, cols, values = append(rows, i), append(cols, 0), append(values, 1.0)
+		for _, word := range strings.Fields(doc) {
+			if _, ok := vocab[word]; !ok {
+				vocab[word] = len(vocab) + 1
+			}
+			rows, cols, values = append(rows, i), append(cols, vocab[word]), append(values, 1.0)
+		}
+	}
+	x, _ := utils.NewCSR(len(docs), len(vocab)+1, rows, cols, values)
+	return x
+}
+
+var (
+	spamDocs = []string{
+		"win free money now", "free prize click now", "cheap money offer",
+		"click to win a prize", "meeting notes free lunch", "urgent offer win cash",
+	}
+	spamLabels = []float64{1.0, 1.0, 1.0, 1.0, 0.0, 1.0}
+	hamDocs    = []string{
+		"team meeting at noon", "project report attached", "lunch with the team",
+		"notes from the project meeting", "review the report now",
+	}
+)
+
+func TestSparseLogistic(t *testing.T) {
+	vocab := map[string]int{}
+	x := bagOfWords(append(slices.Clone(spamDocs), hamDocs...), vocab)
+	labels := append(slices.Clone(spamLabels), 0.0, 0.0, 0.0, 0.0, 0.0)
+	_, ncol := x.Shape()
+	beta := make([]float64, ncol)
+	for i := range beta {
+		beta[i] = 0.1 * float64(i%3)
+	}
+
+	// the sparse likelihood and gradient agree with the dense ones
+	dense := x.Dense().ToRows()
+	if sparse, want := SparseLogisticLogLikelihood(x, labels, beta), LogisticLogLikelihood(dense, labels, beta); math.Abs(sparse-want) > 1e-12 {
+		t.Fatalf("SparseLogisticLogLikelihood(x, y, beta) = %f; want %f", sparse, want)
+	}
+	grad, want := SparseLogisticLogGradient(x, labels, beta), make([]float64, ncol)
+	for i, xi := range dense {
+		want, _ = utils.VectorAdd(want, LogisticLogGradientX(xi, labels[i], beta))
+	}
+	for j := range grad {
+		if math.Abs(grad[j]-want[j]) > 1e-12 {
+			t.Fatalf("SparseLogisticLogGradient(x, y, beta) = %v; want %v", grad, want)
+		}
+	}
+
+	fit := EstimateBetaSparse(x, labels, rand.New(rand.NewSource(42)))
+	if again := EstimateBetaSparse(x, labels, rand.New(rand.NewSource(42))); !slices.Equal(fit, again) {
+		t.Fatalf("EstimateBetaSparse with seed 42 = %v, then %v; want equal", fit, again)
+	}
+	test := bagOfWords([]string{"free money prize", "team project meeting"}, vocab)
+	for i, want := range []float64{1.0, 0.0} {
+		dot, _ := test.Row(i).DotDense(fit)
+		if p := Logistic(dot); math.Round(p) != want {
+			t.Fatalf("EstimateBetaSparse(x, y) gives P(spam) = %f for row %d; want near %v", p, i, want)
+		}
+	}
+}
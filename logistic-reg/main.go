@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"math/rand"
 
 	"github.com/dcooper46/go-ds-from-scratch/utils"
 )
@@ -80,7 +81,8 @@ func main() {
 	if err != nil {
 		log.Fatalf("error normalizing data: %e", err)
 	}
-	xTrain, xTest, yTrain, yTest := utils.TrainTestSplit(normX, y, 0.33)
+	r := rand.New(rand.NewSource(42))
+	xTrain, xTest, yTrain, yTest := utils.TrainTestSplit(normX, y, 0.33, r)
 
 	fmt.Printf(
 		"xtrain: %d, xtest: %d, ytrain: %d, ytest: %d\n",
@@ -91,16 +93,18 @@ func main() {
 	)
 	fmt.Println(xTrain[:3])
 	fmt.Println(yTrain[:3])
-	beta := EstimateBeta(xTrain, yTrain)
+	beta := EstimateBeta(xTrain, yTrain, r)
 	fmt.Println(beta)
 
 	predictions := make([]float64, len(yTest))
+	probs := make([]float64, len(yTest))
 	for i, xi := range xTest {
 		dot, err := utils.Dot(xi, beta)
 		if err != nil {
 			log.Fatalf("error performing dot product: %e", err)
 		}
 		prob := Logistic(dot)
+		probs[i] = prob
 		if prob > 0.5 {
 			predictions[i] = 1.0
 		} else {
@@ -113,4 +117,26 @@ func main() {
 
 	fmt.Printf("precision: %f\n", utils.Precision(confMat))
 	fmt.Printf("recall: %f\n", utils.Recall(confMat))
+
+	// compare across thresholds instead of only at 0.5
+	roc, err := utils.ROC(yTest, probs)
+	if err != nil {
+		log.Fatalf("error computing roc curve: %s", err)
+	}
+	pr, err := utils.PrecisionRecall(yTest, probs)
+	if err != nil {
+		log.Fatalf("error computing precision-recall curve: %s", err)
+	}
+	threshold, j := roc.YoudenThreshold()
+	fmt.Printf("auc: %f, average precision: %f\n", roc.AUC(), pr.AveragePrecision())
+	fmt.Printf("youden threshold: %f (J = %f)\n", threshold, j)
+
+	// how far the probabilities themselves can be trusted
+	logLoss, err := utils.LogLoss(yTest, probs)
+	if err != nil {
+		log.Fatalf("error computing log loss: %s", err)
+	}
+	brier, _ := utils.BrierScore(yTest, probs)
+	ece, _ := utils.ExpectedCalibrationError(yTest, probs, 10)
+	fmt.Printf("log loss: %f, brier score: %f, calibration error: %f\n", logLoss, brier, ece)
 }
@@ -0,0 +1,95 @@
+package mcmc
+
+import (
+	"math"
+)
+
+// splitChains returns the draws of parameter j with every chain cut in
+// half, so chains that drift as well as chains that disagree are caught
+func (r *Result) splitChains(j int) [][]float64 {
+	var split [][]float64
+	for _, chain := range r.Chains {
+		half := len(chain) / 2
+		first, second := make([]float64, half), make([]float64, half)
+		for s := 0; s < half; s++ {
+			first[s] = chain[s][j]
+			second[s] = chain[len(chain)-half+s][j]
+		}
+		split = append(split, first, second)
+	}
+	return split
+}
+
+// varianceParts returns the mean within-chain variance W and the
+// pooled estimate of the posterior variance from the chains
+func varianceParts(chains [][]float64) (within, pooled float64) {
+	m, n := float64(len(chains)), float64(len(chains[0]))
+	means := make([]float64, len(chains))
+	var grand float64
+	for c, chain := range chains {
+		for _, v := range chain {
+			means[c] += v
+		}
+		means[c] /= n
+		grand += means[c] / m
+	}
+	var between float64
+	for c, chain := range chains {
+		var ss float64
+		for _, v := range chain {
+			ss += (v - means[c]) * (v - means[c])
+		}
+		within += ss / (n - 1.0) / m
+		between += (means[c] - grand) * (means[c] - grand)
+	}
+	between *= n / (m - 1.0)
+	return within, (n-1.0)/n*within + between/n
+}
+
+// RHat returns the split potential scale reduction factor of each
+// parameter, which compares the variance between chains to the variance
+// within them.  Values near 1 suggest the chains have converged; values
+// above 1.01 suggest running them longer.
+func (r *Result) RHat() []float64 {
+	rhat := make([]float64, len(r.Chains[0][0]))
+	for j := range rhat {
+		within, pooled := varianceParts(r.splitChains(j))
+		rhat[j] = math.Sqrt(pooled / within)
+	}
+	return rhat
+}
+
+// ESS returns the effective sample size of each parameter, the number of
+// independent draws that would estimate its mean as precisely as the
+// correlated draws of the chains.  After the first lag, autocorrelations
+// are summed in pairs until a pair is negative, following Geyer's initial
+// positive sequence as in Gelman et al., Bayesian Data Analysis.
+func (r *Result) ESS() []float64 {
+	ess := make([]float64, len(r.Chains[0][0]))
+	for j := range ess {
+		chains := r.splitChains(j)
+		m, n := len(chains), len(chains[0])
+		_, pooled := varianceParts(chains)
+		// rho(t) = 1 - variogram(t) / (2 * pooled)
+		rho := func(t int) float64 {
+			var v float64
+			for _, chain := range chains {
+				for s := t; s < n; s++ {
+					v += (chain[s] - chain[s-t]) * (chain[s] - chain[s-t])
+				}
+			}
+			v /= float64(m * (n - t))
+			return 1.0 - v/(2.0*pooled)
+		}
+		sum := rho(1)
+		for t := 2; t+1 < n; t += 2 {
+			pair := rho(t) + rho(t+1)
+			if pair < 0.0 {
+				break
+			}
+			sum += pair
+		}
+		ess[j] = float64(m*n) / (1.0 + 2.0*sum)
+	}
+	return ess
+}
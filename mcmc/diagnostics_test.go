@@ -0,0 +1,37 @@
+package mcmc
+
+import (
+	"math"
+	"testing"
+)
+
+func round3(x float64) float64 {
+	return math.Round(x*1000) / 1000
+}
+
+// toResult wraps chains of a single parameter
+func toResult(chains [][]float64) *Result {
+	r := &Result{}
+	for _, chain := range chains {
+		draws := make([][]float64, len(chain))
+		for s, v := range chain {
+			draws[s] = []float64{v}
+		}
+		r.Chains = append(r.Chains, draws)
+	}
+	return r
+}
+
+func TestRHat(t *testing.T) {
+	r := toResult([][]float64{
+		{0.1, 0.5, 0.3, 0.9, 0.7, 1.1, 0.4, 0.8},
+		{1.2, 1.0, 1.5, 1.3, 1.8, 1.4, 1.6, 2.0},
+		{0.0, -0.3, 0.2, 0.1, 0.4, -0.1, 0.3, 0.5},
+	})
+	if actual := round3(r.RHat()[0]); actual != 2.544 {
+		t.Fatalf("RHat() = %f; want 2.544", actual)
+	}
+	if actual := round3(r.ESS()[0]); actual != 3.925 {
+		t.Fatalf("ESS() = %f; want 3.925", actual)
+	}
+}
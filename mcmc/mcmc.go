@@ -0,0 +1,154 @@
+// Package mcmc draws samples from probability distributions known only up
+// to a constant, such as Bayesian posteriors without a conjugate prior,
+// using Markov chain Monte Carlo.  Several chains run in parallel, each
+// seeded in order from a single seed so results are reproducible, and
+// their draws can be checked for convergence with R-hat and the effective
+// sample size.
+package mcmc
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+)
+
+// NoBurnIn asks for every iteration to be kept, for chains started at a
+// known mode or continuing an earlier run, since a BurnIn of 0 means the
+// default
+const NoBurnIn = -1
+
+// Options controls a sampling run
+type Options struct {
+	// Samples is the number of draws kept from each chain, 1000 if 0
+	Samples int
+	// BurnIn is the number of initial iterations of each chain that are
+	// discarded while proposals adapt, the same as Samples if 0 and none
+	// if NoBurnIn
+	BurnIn int
+	// Thin keeps every Thin-th iteration after burn-in, 1 if 0
+	Thin int
+	// Chains is the number of chains, each run in its own goroutine, 4 if 0
+	Chains int
+	// Seed seeds the chains
+	Seed int64
+}
+
+// Result holds the draws of every chain
+type Result struct {
+	// Chains[c][s] is the s-th draw of the parameters kept from chain c
+	Chains [][][]float64
+	// AcceptanceRate is the share of proposals accepted by each chain
+	// after burn-in
+	AcceptanceRate []float64
+}
+
+// kernel advances a chain by one iteration, updating theta in place and
+// reporting the number of proposals accepted and made.  While burning in
+// a kernel may tune its proposals.
+type kernel interface {
+	step(theta []float64, r *rand.Rand, burnIn bool) (accepted, proposed int)
+}
+
+// withDefaults fills in zero options and checks the rest
+func (o Options) withDefaults() (Options, error) {
+	if o.Samples == 0 {
+		o.Samples = 1000
+	}
+	switch o.BurnIn {
+	case 0:
+		o.BurnIn = o.Samples
+	case NoBurnIn:
+		o.BurnIn = 0
+	}
+	if o.Thin == 0 {
+		o.Thin = 1
+	}
+	if o.Chains == 0 {
+		o.Chains = 4
+	}
+	if o.Samples < 4 {
+		return o, fmt.Errorf("need at least 4 samples per chain, got %d", o.Samples)
+	}
+	if o.BurnIn < 0 || o.Thin < 0 || o.Chains < 0 {
+		return o, fmt.Errorf("burn-in, thinning and chains must be non-negative, got %d, %d and %d",
+			o.BurnIn, o.Thin, o.Chains)
+	}
+	return o, nil
+}
+
+// run starts every chain from init in its own goroutine.  Each chain
+// draws from its own generator, seeded in order from opts.Seed.
+func run(init []float64, opts Options, newKernel func() kernel) (*Result, error) {
+	if len(init) == 0 {
+		return nil, fmt.Errorf("need at least 1 parameters, got 0")
+	}
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	seeds := make([]int64, opts.Chains)
+	master := rand.New(rand.NewSource(opts.Seed))
+	for c := range seeds {
+		seeds[c] = master.Int63()
+	}
+
+	res := &Result{
+		Chains:         make([][][]float64, opts.Chains),
+		AcceptanceRate: make([]float64, opts.Chains),
+	}
+	var wg sync.WaitGroup
+	for c := 0; c < opts.Chains; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seeds[c]))
+			k := newKernel()
+			theta := slices.Clone(init)
+			for i := 0; i < opts.BurnIn; i++ {
+				k.step(theta, r, true)
+			}
+			var accepted, proposed int
+			draws := make([][]float64, opts.Samples)
+			for s := range draws {
+				for i := 0; i < opts.Thin; i++ {
+					a, p := k.step(theta, r, false)
+					accepted += a
+					proposed += p
+				}
+				draws[s] = slices.Clone(theta)
+			}
+			res.Chains[c] = draws
+			res.AcceptanceRate[c] = 1.0
+			if proposed > 0 {
+				res.AcceptanceRate[c] = float64(accepted) / float64(proposed)
+			}
+		}(c)
+	}
+	wg.Wait()
+	return res, nil
+}
+
+// Draws returns the draws of every chain pooled together
+func (r *Result) Draws() [][]float64 {
+	var draws [][]float64
+	for _, chain := range r.Chains {
+		draws = append(draws, chain...)
+	}
+	return draws
+}
+
+// Mean returns the mean of each parameter over the pooled draws
+func (r *Result) Mean() []float64 {
+	draws := r.Draws()
+	mean := make([]float64, len(draws[0]))
+	for _, d := range draws {
+		for j, v := range d {
+			mean[j] += v
+		}
+	}
+	for j := range mean {
+		mean[j] /= float64(len(draws))
+	}
+	return mean
+}
@@ -0,0 +1,111 @@
+package mcmc
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Proposal step sizes adapt in batches of iterations during burn-in,
+// toward the acceptance rate that is optimal for one dimensional
+// random walk updates
+const (
+	adaptBatch       = 50
+	targetAcceptance = 0.44
+)
+
+// metropolis updates one parameter at a time by a gaussian random walk,
+// each with its own step size
+type metropolis struct {
+	logDensity func(theta []float64) float64
+	current    float64
+	logSteps   []float64
+	// acceptance counts and iterations in the current adaptation batch
+	batchAccepted []int
+	batchIters    int
+	batches       int
+}
+
+func (m *metropolis) step(theta []float64, r *rand.Rand, burnIn bool) (accepted, proposed int) {
+	for j := range theta {
+		old := theta[j]
+		theta[j] = old + math.Exp(m.logSteps[j])*r.NormFloat64()
+		lp := m.logDensity(theta)
+		if math.Log(r.Float64()) < lp-m.current {
+			m.current = lp
+			m.batchAccepted[j]++
+			accepted++
+		} else {
+			theta[j] = old
+		}
+		proposed++
+	}
+	if !burnIn {
+		return accepted, proposed
+	}
+
+	// grow a step when more than the target share of its proposals
+	// were accepted and shrink it otherwise, by less each batch
+	m.batchIters++
+	if m.batchIters == adaptBatch {
+		m.batches++
+		delta := 1.0 / math.Sqrt(float64(m.batches))
+		for j, a := range m.batchAccepted {
+			if float64(a)/adaptBatch > targetAcceptance {
+				m.logSteps[j] += delta
+			} else {
+				m.logSteps[j] -= delta
+			}
+			m.batchAccepted[j] = 0
+		}
+		m.batchIters = 0
+	}
+	return accepted, proposed
+}
+
+// MetropolisHastings samples from the distribution whose density is
+// proportional to exp(logDensity), starting every chain from init.
+// Parameters are updated one at a time by gaussian random walk proposals,
+// whose step sizes adapt during burn-in to accept about 44% of proposals
+// and are then held fixed, so the kept draws form a valid Markov chain.
+// logDensity may return -Inf outside the support of the distribution.
+func MetropolisHastings(logDensity func(theta []float64) float64, init []float64, opts Options) (*Result, error) {
+	lp := logDensity(init)
+	if math.IsInf(lp, 0) || math.IsNaN(lp) {
+		return nil, fmt.Errorf("initial values must have finite log density, got %f", lp)
+	}
+	return run(init, opts, func() kernel {
+		return &metropolis{
+			logDensity:    logDensity,
+			current:       lp,
+			logSteps:      make([]float64, len(init)),
+			batchAccepted: make([]int, len(init)),
+		}
+	})
+}
+
+// Update draws some of the parameters from their distribution
+// conditional on the rest, updating theta in place
+type Update func(theta []float64, r *rand.Rand)
+
+// gibbs applies each update in turn
+type gibbs []Update
+
+func (g gibbs) step(theta []float64, r *rand.Rand, burnIn bool) (accepted, proposed int) {
+	for _, update := range g {
+		update(theta, r)
+	}
+	return 0, 0
+}
+
+// Gibbs samples from a joint distribution by drawing each block of
+// parameters from its full conditional distribution in turn, starting
+// every chain from init.  Every draw is accepted.
+func Gibbs(updates []Update, init []float64, opts Options) (*Result, error) {
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("need at least 1 updates, got 0")
+	}
+	return run(init, opts, func() kernel {
+		return gibbs(updates)
+	})
+}
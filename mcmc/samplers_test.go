@@ -0,0 +1,125 @@
+package mcmc
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+// logNormal is the log density of independent normals with
+// means (1, -2) and standard deviations (1, 3), up to a constant
+func logNormal(theta []float64) float64 {
+	a, b := theta[0]-1.0, (theta[1]+2.0)/3.0
+	return -0.5 * (a*a + b*b)
+}
+
+func TestMetropolisHastings(t *testing.T) {
+	res, err := MetropolisHastings(logNormal, []float64{0.0, 0.0}, Options{Samples: 2000, Seed: 42})
+	if err != nil {
+		t.Fatalf("error calling MetropolisHastings(logNormal): %s", err)
+	}
+	if len(res.Chains) != 4 || len(res.Chains[0]) != 2000 {
+		t.Fatalf("MetropolisHastings kept %d chains of %d draws; want 4 of 2000", len(res.Chains), len(res.Chains[0]))
+	}
+	mean := res.Mean()
+	if math.Abs(mean[0]-1.0) > 0.1 || math.Abs(mean[1]+2.0) > 0.3 {
+		t.Fatalf("MetropolisHastings mean = %v; want [1 -2]", mean)
+	}
+	sd, _ := utils.StandardDeviation(utils.GetColumn(res.Draws(), 1))
+	if math.Abs(sd-3.0) > 0.3 {
+		t.Fatalf("MetropolisHastings standard deviation of theta[1] = %f; want 3", sd)
+	}
+	for c, rate := range res.AcceptanceRate {
+		if math.Abs(rate-targetAcceptance) > 0.1 {
+			t.Fatalf("MetropolisHastings acceptance rate of chain %d = %f; want about %f", c, rate, targetAcceptance)
+		}
+	}
+	for j, rhat := range res.RHat() {
+		if rhat > 1.01 {
+			t.Fatalf("MetropolisHastings RHat()[%d] = %f; want below 1.01", j, rhat)
+		}
+	}
+	for j, ess := range res.ESS() {
+		if ess < 1000.0 || ess > 8000.0 {
+			t.Fatalf("MetropolisHastings ESS()[%d] = %f; want between 1000 and 8000", j, ess)
+		}
+	}
+}
+
+func TestMetropolisHastingsReproducible(t *testing.T) {
+	opts := Options{Samples: 100, BurnIn: 100, Thin: 3, Chains: 3, Seed: 7}
+	a, _ := MetropolisHastings(logNormal, []float64{0.0, 0.0}, opts)
+	b, _ := MetropolisHastings(logNormal, []float64{0.0, 0.0}, opts)
+	for c := range a.Chains {
+		for s := range a.Chains[c] {
+			for j := range a.Chains[c][s] {
+				if a.Chains[c][s][j] != b.Chains[c][s][j] {
+					t.Fatalf("MetropolisHastings with seed 7 draw [%d][%d] = %v, then %v; want equal",
+						c, s, a.Chains[c][s], b.Chains[c][s])
+				}
+			}
+		}
+	}
+}
+
+func TestMetropolisHastingsErrors(t *testing.T) {
+	positive := func(theta []float64) float64 {
+		if theta[0] <= 0.0 {
+			return math.Inf(-1)
+		}
+		return -theta[0]
+	}
+	if _, err := MetropolisHastings(positive, []float64{-1.0}, Options{}); err == nil {
+		t.Fatalf("MetropolisHastings outside the support should raise error: initial values must have finite log density")
+	}
+	if _, err := MetropolisHastings(logNormal, []float64{0.0, 0.0}, Options{Samples: 2}); err == nil {
+		t.Fatalf("MetropolisHastings with 2 samples should raise error: need at least 4 samples per chain")
+	}
+	if _, err := MetropolisHastings(logNormal, []float64{0.0, 0.0}, Options{Thin: -1}); err == nil {
+		t.Fatalf("MetropolisHastings with negative thinning should raise error: must be non-negative")
+	}
+}
+
+func TestGibbs(t *testing.T) {
+	// a standard bivariate normal with correlation rho, whose
+	// conditionals are normal with mean rho times the other coordinate
+	rho := 0.8
+	sd := math.Sqrt(1.0 - rho*rho)
+	updates := []Update{
+		func(theta []float64, r *rand.Rand) { theta[0] = rho*theta[1] + sd*r.NormFloat64() },
+		func(theta []float64, r *rand.Rand) { theta[1] = rho*theta[0] + sd*r.NormFloat64() },
+	}
+	res, err := Gibbs(updates, []float64{3.0, -3.0}, Options{Samples: 5000, BurnIn: 100, Seed: 42})
+	if err != nil {
+		t.Fatalf("error calling Gibbs(updates): %s", err)
+	}
+	mean := res.Mean()
+	if math.Abs(mean[0]) > 0.1 || math.Abs(mean[1]) > 0.1 {
+		t.Fatalf("Gibbs mean = %v; want [0 0]", mean)
+	}
+	draws := res.Draws()
+	corr, _ := utils.Correlation(utils.GetColumn(draws, 0), utils.GetColumn(draws, 1))
+	if math.Abs(corr-rho) > 0.02 {
+		t.Fatalf("Gibbs correlation = %f; want %f", corr, rho)
+	}
+	if res.AcceptanceRate[0] != 1.0 {
+		t.Fatalf("Gibbs acceptance rate = %f; want 1", res.AcceptanceRate[0])
+	}
+
+	// counting iterations shows how many were burned in
+	count := []Update{func(theta []float64, r *rand.Rand) { theta[0]++ }}
+	for _, test := range []struct {
+		burnIn int
+		first  float64
+	}{{0, 5.0}, {NoBurnIn, 1.0}, {2, 3.0}} {
+		res, _ := Gibbs(count, []float64{0.0}, Options{Samples: 4, BurnIn: test.burnIn, Chains: 1})
+		if actual := res.Chains[0][0][0]; actual != test.first {
+			t.Fatalf("Gibbs with BurnIn %d first draw = %f; want %f", test.burnIn, actual, test.first)
+		}
+	}
+	if _, err := Gibbs(nil, []float64{0.0}, Options{}); err == nil {
+		t.Fatalf("Gibbs with no updates should raise error: need at least 1 updates")
+	}
+}
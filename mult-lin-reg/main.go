@@ -1,6 +1,15 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"log"
+	"math/rand"
+
+	"github.com/dcooper46/go-ds-from-scratch/bootstrap"
+	"github.com/dcooper46/go-ds-from-scratch/hypothesis"
+	"github.com/dcooper46/go-ds-from-scratch/regression"
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
 
 var x = [][]float64{
 	{1.0, 49.0, 4.0, 0.0}, {1.0, 41.0, 9.0, 0.0}, {1.0, 40.0, 8.0, 0.0},
@@ -86,19 +95,82 @@ var dailyMins = []float64{
 }
 
 func main() {
-	beta := EstimateBeta(x, dailyMins)
+	report, err := utils.DiagnoseCollinearity(x, 0.0)
+	if err != nil {
+		log.Fatalf("error diagnosing design matrix: %s", err)
+	}
+	fmt.Println(report)
+
+	phd := utils.GetColumn(x, 3)
+	anova, err := hypothesis.OneWayANOVA(dailyMins, phd)
+	if err != nil {
+		log.Fatalf("error running anova: %s", err)
+	}
+	fmt.Println(anova)
+	comparisons, err := hypothesis.TukeyHSD(dailyMins, phd, 0.95)
+	if err != nil {
+		log.Fatalf("error running tukey hsd: %s", err)
+	}
+	for _, c := range comparisons {
+		fmt.Println(c)
+	}
+
+	r := rand.New(rand.NewSource(42))
+	beta := EstimateBeta(x, dailyMins, r)
 	fmt.Println(beta)
 	fmt.Println(RSquared(x, dailyMins, beta))
 
-	betaR0 := EstimateBetaRidge(x, dailyMins, 0.001)
+	betaR0 := EstimateBetaRidge(x, dailyMins, 0.001, r)
 	fmt.Println(betaR0)
 	fmt.Println(RSquared(x, dailyMins, betaR0))
 
-	betaR1 := EstimateBetaRidge(x, dailyMins, 0.1)
+	betaR1 := EstimateBetaRidge(x, dailyMins, 0.1, r)
 	fmt.Println(betaR1)
 	fmt.Println(RSquared(x, dailyMins, betaR1))
 
-	betaR10 := EstimateBetaRidge(x, dailyMins, 10)
+	betaR10 := EstimateBetaRidge(x, dailyMins, 10, r)
 	fmt.Println(betaR10)
 	fmt.Println(RSquared(x, dailyMins, betaR10))
+
+	betaExact, err := EstimateBetaExact(x, dailyMins)
+	if err != nil {
+		log.Fatalf("error solving least squares: %s", err)
+	}
+	fmt.Println(betaExact)
+	fmt.Println(RSquared(x, dailyMins, betaExact))
+
+	summary, err := regression.Summarize(x, dailyMins, betaExact,
+		[]string{"(Intercept)", "friends", "work_hours", "phd"})
+	if err != nil {
+		log.Fatalf("error summarizing fit: %s", err)
+	}
+	fmt.Println(summary)
+
+	diagnostics, err := regression.Diagnose(x, dailyMins, betaExact)
+	if err != nil {
+		log.Fatalf("error diagnosing fit: %s", err)
+	}
+	fmt.Println(diagnostics)
+
+	boot, err := BootstrapBeta(x, dailyMins, bootstrap.Options{Replicates: 100})
+	if err != nil {
+		log.Fatalf("error bootstrapping coefficients: %s", err)
+	}
+	stdErrs, err := boot.StdErr()
+	if err != nil {
+		log.Fatalf("error computing standard errors: %s", err)
+	}
+	fmt.Println(stdErrs)
+	intervals, err := boot.BCa(0.95)
+	if err != nil {
+		log.Fatalf("error computing confidence intervals: %s", err)
+	}
+	fmt.Println(intervals)
+
+	betaR1Exact, err := EstimateBetaRidgeExact(x, dailyMins, 0.1)
+	if err != nil {
+		log.Fatalf("error solving ridge normal equations: %s", err)
+	}
+	fmt.Println(betaR1Exact)
+	fmt.Println(RSquared(x, dailyMins, betaR1Exact))
 }
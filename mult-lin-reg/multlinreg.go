@@ -5,6 +5,7 @@ import (
 	"math"
 	"math/rand"
 
+	"github.com/dcooper46/go-ds-from-scratch/bootstrap"
 	"github.com/dcooper46/go-ds-from-scratch/utils"
 )
 
@@ -29,15 +30,6 @@ func SquaredError(x []float64, y float64, beta []float64) float64 {
 	return math.Pow(Error(x, y, beta), 2)
 }
 
-// TotalSumOfSquares gives the unnormalized variace
-func TotalSumOfSquares(y []float64) (tss float64) {
-	muy := utils.Mean(y)
-	for _, yi := range y {
-		tss += math.Pow(yi-muy, 2)
-	}
-	return
-}
-
 // SquaredErrorGradient gives the gradient for the squared error function
 func SquaredErrorGradient(x []float64, y float64, beta []float64) []float64 {
 	grad := make([]float64, len(x))
@@ -48,30 +40,66 @@ func SquaredErrorGradient(x []float64, y float64, beta []float64) []float64 {
 }
 
 // EstimateBeta uses stochastic gradient decent to find
-// coefficents that minimize the squared loss
-func EstimateBeta(x [][]float64, y []float64) []float64 {
+// coefficents that minimize the squared loss, starting
+// from coefficients drawn from r
+func EstimateBeta(x [][]float64, y []float64, r *rand.Rand) []float64 {
 	betaInit := make([]float64, len(x[0]))
 	for i := range betaInit {
-		betaInit[i] = rand.Float64()
+		betaInit[i] = r.Float64()
 	}
 	return utils.StochasticGradientDecent(
 		SquaredError,
 		SquaredErrorGradient,
 		x,
-		dailyMins,
+		y,
 		betaInit,
 		0.001,
 		10,
 	)
 }
 
+// EstimateBetaExact finds the coefficents that minimize the squared loss
+// by solving the least squares problem directly with a QR factorization
+func EstimateBetaExact(x [][]float64, y []float64) ([]float64, error) {
+	xMat, err := utils.FromRows(x)
+	if err != nil {
+		return nil, err
+	}
+	return utils.LeastSquares(xMat, y)
+}
+
+// BootstrapBeta refits the coefficients on rows of x and y resampled
+// with replacement, giving their standard errors and intervals
+func BootstrapBeta(x [][]float64, y []float64, opts bootstrap.Options) (*bootstrap.Result, error) {
+	rows := make([]int, len(x))
+	for i := range rows {
+		rows[i] = i
+	}
+	return bootstrap.Run(rows, func(sample []int) ([]float64, error) {
+		xs := make([][]float64, len(sample))
+		ys := make([]float64, len(sample))
+		for i, row := range sample {
+			xs[i], ys[i] = x[row], y[row]
+		}
+		return EstimateBetaExact(xs, ys)
+	}, opts)
+}
+
 // RSquared gives the variance in y explained by the model
 func RSquared(x [][]float64, y []float64, beta []float64) float64 {
-	var sse float64
+	yhat := make([]float64, len(x))
 	for i, xi := range x {
-		sse += SquaredError(xi, y[i], beta)
+		pred, err := Predict(xi, beta)
+		if err != nil {
+			log.Fatalf("prediction error: %s", err)
+		}
+		yhat[i] = pred
 	}
-	return 1.0 - sse/TotalSumOfSquares(y)
+	rSqrd, err := utils.RSquared(y, yhat)
+	if err != nil {
+		log.Fatalf("error computing r-squared: %s", err)
+	}
+	return rSqrd
 }
 
 // RidgePenalty add a penalty proportional to the sum of squares
@@ -139,23 +167,56 @@ func SquaredErrorRidgeGradientAlpha(
 }
 
 // EstimateBetaRidge uses gradient decent to fit parameters
-// with a ridge penalty
+// with a ridge penalty, starting from coefficients drawn from r
 func EstimateBetaRidge(
 	x [][]float64,
 	y []float64,
 	alpha float64,
+	r *rand.Rand,
 ) []float64 {
 	betaInit := make([]float64, len(x[0]))
 	for i := range betaInit {
-		betaInit[i] = rand.Float64()
+		betaInit[i] = r.Float64()
 	}
 	return utils.StochasticGradientDecent(
 		SquaredErrorRidgeAlpha(alpha),
 		SquaredErrorRidgeGradientAlpha(alpha),
 		x,
-		dailyMins,
+		y,
 		betaInit,
 		0.001,
 		50,
 	)
 }
+
+// EstimateBetaRidgeExact fits parameters with a ridge penalty by solving
+// the normal equations (X'X + alpha*I)beta = X'y with a Cholesky factorization.
+// As in RidgePenalty, the intercept beta[0] is not penalized.
+func EstimateBetaRidgeExact(
+	x [][]float64,
+	y []float64,
+	alpha float64,
+) ([]float64, error) {
+	xMat, err := utils.FromRows(x)
+	if err != nil {
+		return nil, err
+	}
+	xt := xMat.T()
+	xtx, err := xt.Mul(xMat)
+	if err != nil {
+		return nil, err
+	}
+	_, ncol := xtx.Shape()
+	for i := 1; i < ncol; i++ {
+		xtx.Set(i, i, xtx.At(i, i)+alpha)
+	}
+	xty, err := xt.MulVec(y)
+	if err != nil {
+		return nil, err
+	}
+	chol, err := utils.NewCholesky(xtx)
+	if err != nil {
+		return nil, err
+	}
+	return chol.Solve(xty)
+}
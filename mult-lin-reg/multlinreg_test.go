@@ -0,0 +1,20 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestEstimateBetaReproducible(t *testing.T) {
+	a := EstimateBeta(x, dailyMins, rand.New(rand.NewSource(42)))
+	b := EstimateBeta(x, dailyMins, rand.New(rand.NewSource(42)))
+	if !slices.Equal(a, b) {
+		t.Fatalf("EstimateBeta with seed 42 = %v, then %v; want equal", a, b)
+	}
+	a = EstimateBetaRidge(x, dailyMins, 0.1, rand.New(rand.NewSource(42)))
+	b = EstimateBetaRidge(x, dailyMins, 0.1, rand.New(rand.NewSource(42)))
+	if !slices.Equal(a, b) {
+		t.Fatalf("EstimateBetaRidge with seed 42 = %v, then %v; want equal", a, b)
+	}
+}
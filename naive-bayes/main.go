@@ -9,10 +9,13 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/dcooper46/go-ds-from-scratch/utils"
 )
 
 var (
 	filesDir = flag.String("files", "", "directory of spam files")
+	seed     = flag.Int64("seed", 42, "seed for the train/test split")
 )
 
 type prediction struct {
@@ -75,9 +78,10 @@ func main() {
 	fmt.Printf("found %d files\n", len(rawData))
 	fmt.Println(rawData[0])
 
+	r := rand.New(rand.NewSource(*seed))
 	var train, test []Record
 	for _, data := range rawData {
-		if rand.Float64() < 0.75 {
+		if r.Float64() < 0.75 {
 			train = append(train, data)
 		} else {
 			test = append(test, data)
@@ -114,4 +118,60 @@ func main() {
 	}
 
 	fmt.Println(counts)
+
+	labels := make([]float64, len(predictions))
+	scores := make([]float64, len(predictions))
+	for i, pred := range predictions {
+		if pred.isSpam {
+			labels[i] = 1.0
+		}
+		scores[i] = pred.predictedProb
+	}
+	roc, err := utils.ROC(labels, scores)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pr, err := utils.PrecisionRecall(labels, scores)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("auc: %f, average precision: %f\n", roc.AUC(), pr.AveragePrecision())
+
+	logLoss, err := utils.LogLoss(labels, scores)
+	if err != nil {
+		log.Fatal(err)
+	}
+	brier, _ := utils.BrierScore(labels, scores)
+	ece, _ := utils.ExpectedCalibrationError(labels, scores, 10)
+	fmt.Printf("log loss: %f, brier score: %f, calibration error: %f\n", logLoss, brier, ece)
+
+	// HitProb multiplies a term for every word in the corpus, so its
+	// probabilities are overconfident.  Fit a calibrator on alternate
+	// test records, which come grouped by class, and judge it on the rest.
+	var fitScores, fitLabels, heldLabels, rawScores, calibrated []float64
+	var heldOut []Record
+	for i, data := range test {
+		if i%2 == 0 {
+			fitScores = append(fitScores, scores[i])
+			fitLabels = append(fitLabels, labels[i])
+		} else {
+			heldOut = append(heldOut, data)
+			heldLabels = append(heldLabels, labels[i])
+			rawScores = append(rawScores, scores[i])
+		}
+	}
+	platt, err := utils.FitPlatt(fitLabels, fitScores)
+	if err != nil {
+		log.Fatal(err)
+	}
+	classify := utils.CalibratedScorer(classifier.Classify, platt)
+	for _, data := range heldOut {
+		calibrated = append(calibrated, classify(data.message))
+	}
+	rawLoss, _ := utils.LogLoss(heldLabels, rawScores)
+	plattLoss, _ := utils.LogLoss(heldLabels, calibrated)
+	rawECE, _ := utils.ExpectedCalibrationError(heldLabels, rawScores, 10)
+	plattECE, _ := utils.ExpectedCalibrationError(heldLabels, calibrated, 10)
+	fmt.Printf("held out log loss: %f raw, %f calibrated\n", rawLoss, plattLoss)
+	fmt.Printf("held out calibration error: %f raw, %f calibrated\n", rawECE, plattECE)
 }
@@ -110,10 +110,9 @@ func HitProb(wordprobs []Wordprob, message string) float64 {
 		}
 	}
 
-	hitProb := math.Exp(hitLogProb)
-	missProb := math.Exp(missLogProb)
-
-	return hitProb / (hitProb + missProb)
+	// exp(hit) / (exp(hit) + exp(miss)) is 0/0 once both products of
+	// thousands of word probabilities underflow, so divide through first
+	return 1.0 / (1.0 + math.Exp(missLogProb-hitLogProb))
 }
 
 // NaiveBayesClassifier implements a simple naive bayes algorithm
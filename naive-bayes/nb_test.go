@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestHitProbUnderflow(t *testing.T) {
+	// enough rare words that both products underflow to 0
+	wordprobs := make([]Wordprob, 2000)
+	for i := range wordprobs {
+		wordprobs[i] = Wordprob{word: fmt.Sprintf("w%d", i), hitprob: 0.3, missprob: 0.2}
+	}
+	message := ""
+	for i := 0; i < 1000; i++ {
+		message += fmt.Sprintf("w%d ", i)
+	}
+	if actual := HitProb(wordprobs, message); math.IsNaN(actual) || actual < 0.999 {
+		t.Fatalf("HitProb(wordprobs, message) = %f; want close to 1", actual)
+	}
+	if actual := HitProb(wordprobs[:1], "w0"); math.Abs(actual-0.6) > 1e-12 {
+		t.Fatalf("HitProb([w0 0.3 0.2], w0) = %f; want 0.6", actual)
+	}
+}
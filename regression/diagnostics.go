@@ -0,0 +1,255 @@
+package regression
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dcooper46/go-ds-from-scratch/distributions"
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+// Thresholds above which observations and columns are flagged
+const (
+	// leverage is flagged above leverageFactor * p / n
+	leverageFactor = 2.0
+	// Cook's distance is flagged above cooksFactor / n
+	cooksFactor = 4.0
+	// studentized residuals are flagged beyond +-studentizedLimit
+	studentizedLimit = 3.0
+	// variance inflation factors are flagged above vifLimit
+	vifLimit = 10.0
+	// leverage within leverageTolerance of 1 means the fit passes through
+	// the observation, so its residual says nothing about it
+	leverageTolerance = 1e-10
+	// the Breusch-Pagan test is flagged below heteroskedasticityAlpha
+	heteroskedasticityAlpha = 0.05
+)
+
+// BreuschPagan holds the outcome of Koenker's studentized Breusch-Pagan
+// test, which regresses the squared residuals on the predictors.
+// A small p-value suggests the error variance is not constant.
+type BreuschPagan struct {
+	Statistic float64
+	DF        int
+	PValue    float64
+}
+
+// Flagged is an observation that looks unusual
+// along with the reasons it was flagged
+type Flagged struct {
+	Index   int
+	Reasons []string
+}
+
+// Diagnostics checks the assumptions of a fitted linear model
+type Diagnostics struct {
+	Residuals []float64
+	// Leverage holds the diagonal of the hat matrix X(X'X)^-1X'
+	Leverage []float64
+	// Studentized holds the externally studentized residuals, each
+	// scaled by an error variance estimated without that observation
+	Studentized []float64
+	// CooksDistance holds the influence of each observation on the fit.
+	// Both it and Studentized are NaN for observations with leverage 1,
+	// such as one picked out by its own dummy column.
+	CooksDistance []float64
+	// DurbinWatson is near 2 when residuals are not autocorrelated
+	DurbinWatson float64
+	BreuschPagan BreuschPagan
+	// VIF holds the variance inflation factor of each column,
+	// NaN for the intercept
+	VIF []float64
+	// Flagged lists the unusual observations in order
+	Flagged []Flagged
+	// Collinear lists the columns with a large variance inflation factor
+	Collinear []int
+}
+
+// rSquared fits y on the columns of x by least squares and returns
+// the fraction of variance explained, centered when x has an intercept
+func rSquared(x [][]float64, y []float64) (float64, error) {
+	xMat, err := utils.FromRows(x)
+	if err != nil {
+		return 0.0, err
+	}
+	beta, err := utils.LeastSquares(xMat, y)
+	if err != nil {
+		return 0.0, err
+	}
+	res, err := residuals(xMat, y, beta)
+	if err != nil {
+		return 0.0, err
+	}
+	rss, _ := utils.SumOfSquares(res)
+	tss, _ := utils.SumOfSquares(y)
+	if hasIntercept(x) {
+		tss, _ = utils.TotalSumOfSquares(y)
+	}
+	if tss == 0.0 {
+		return 0.0, nil
+	}
+	return 1.0 - rss/tss, nil
+}
+
+// dropColumn returns x without column j
+func dropColumn(x [][]float64, j int) [][]float64 {
+	out := make([][]float64, len(x))
+	for i, row := range x {
+		out[i] = make([]float64, 0, len(row)-1)
+		out[i] = append(out[i], row[:j]...)
+		out[i] = append(out[i], row[j+1:]...)
+	}
+	return out
+}
+
+// Diagnose computes residual diagnostics for coefficients beta fit by
+// least squares to the design matrix x and response y, and flags
+// observations with high leverage (above 2p/n), large Cook's distance
+// (above 4/n) or large studentized residuals (beyond +-3), and columns
+// with variance inflation factors above 10.
+func Diagnose(x [][]float64, y, beta []float64) (*Diagnostics, error) {
+	xMat, err := utils.FromRows(x)
+	if err != nil {
+		return nil, err
+	}
+	n, p := xMat.Shape()
+	if len(y) != n {
+		return nil, fmt.Errorf("vectors are of unequal size: %d != %d", len(y), n)
+	}
+	if p == 0 {
+		return nil, fmt.Errorf("design matrix has no columns")
+	}
+	if len(beta) != p {
+		return nil, fmt.Errorf("incompatible shapes: (%d,%d), (%d,1)", n, p, len(beta))
+	}
+	if n <= p+1 {
+		return nil, fmt.Errorf("need at least 2 more observations than coefficients, got %d and %d", n, p)
+	}
+	cov, err := xtxInverse(xMat)
+	if err != nil {
+		return nil, err
+	}
+	res, err := residuals(xMat, y, beta)
+	if err != nil {
+		return nil, err
+	}
+	rss, _ := utils.SumOfSquares(res)
+	sigma2 := rss / float64(n-p)
+
+	d := &Diagnostics{
+		Residuals:     res,
+		Leverage:      make([]float64, n),
+		Studentized:   make([]float64, n),
+		CooksDistance: make([]float64, n),
+	}
+	for i, row := range x {
+		// h_ii = x_i' (X'X)^-1 x_i
+		v, _ := cov.MulVec(row)
+		h, _ := utils.Dot(row, v)
+		e := res[i]
+		d.Leverage[i] = h
+		if h >= 1.0-leverageTolerance {
+			d.Studentized[i] = math.NaN()
+			d.CooksDistance[i] = math.NaN()
+			continue
+		}
+		looSigma2 := (rss - e*e/(1.0-h)) / float64(n-p-1)
+		d.Studentized[i] = e / math.Sqrt(looSigma2*(1.0-h))
+		d.CooksDistance[i] = e * e * h / (float64(p) * sigma2 * (1.0 - h) * (1.0 - h))
+	}
+
+	var num float64
+	for i := 1; i < n; i++ {
+		num += (res[i] - res[i-1]) * (res[i] - res[i-1])
+	}
+	d.DurbinWatson = num / rss
+
+	intercept := hasIntercept(x)
+	squared := make([]float64, n)
+	for i, e := range res {
+		squared[i] = e * e
+	}
+	r2, err := rSquared(x, squared)
+	if err != nil {
+		return nil, err
+	}
+	df := p
+	if intercept {
+		df = p - 1
+	}
+	d.BreuschPagan = BreuschPagan{Statistic: float64(n) * r2, DF: df, PValue: math.NaN()}
+	if df > 0 {
+		d.BreuschPagan.PValue = distributions.RegIncGammaUpper(float64(df)/2.0, d.BreuschPagan.Statistic/2.0)
+	}
+
+	d.VIF = make([]float64, p)
+	for j := range d.VIF {
+		switch {
+		case j == 0 && intercept:
+			d.VIF[j] = math.NaN()
+			continue
+		case p == 1 || (intercept && p == 2):
+			d.VIF[j] = 1.0
+			continue
+		}
+		r2, err := rSquared(dropColumn(x, j), utils.GetColumn(x, j))
+		if err != nil {
+			return nil, err
+		}
+		d.VIF[j] = 1.0 / (1.0 - r2)
+		if d.VIF[j] > vifLimit {
+			d.Collinear = append(d.Collinear, j)
+		}
+	}
+
+	levLimit := leverageFactor * float64(p) / float64(n)
+	cooksLimit := cooksFactor / float64(n)
+	for i := 0; i < n; i++ {
+		var reasons []string
+		if d.Leverage[i] > levLimit {
+			reasons = append(reasons, fmt.Sprintf("leverage %.3f > %.3f", d.Leverage[i], levLimit))
+		}
+		if d.CooksDistance[i] > cooksLimit {
+			reasons = append(reasons, fmt.Sprintf("cook's distance %.3f > %.3f", d.CooksDistance[i], cooksLimit))
+		}
+		if math.Abs(d.Studentized[i]) > studentizedLimit {
+			reasons = append(reasons, fmt.Sprintf("studentized residual %.3f", d.Studentized[i]))
+		}
+		if reasons != nil {
+			d.Flagged = append(d.Flagged, Flagged{Index: i, Reasons: reasons})
+		}
+	}
+	return d, nil
+}
+
+// Heteroskedastic reports whether the Breusch-Pagan test
+// rejects constant error variance at the 5% level
+func (d *Diagnostics) Heteroskedastic() bool {
+	return d.BreuschPagan.PValue < heteroskedasticityAlpha
+}
+
+// String lists the test statistics and the flagged observations and columns
+func (d *Diagnostics) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Durbin-Watson: %.4f\n", d.DurbinWatson)
+	bp := d.BreuschPagan
+	fmt.Fprintf(&sb, "Breusch-Pagan: BP = %.4f, df = %d, p-value = %.4g", bp.Statistic, bp.DF, bp.PValue)
+	if d.Heteroskedastic() {
+		sb.WriteString(" (heteroskedastic)")
+	}
+	sb.WriteString("\nVIF:")
+	for j, v := range d.VIF {
+		if !math.IsNaN(v) {
+			fmt.Fprintf(&sb, " [%d] %.3f", j, v)
+		}
+	}
+	for _, j := range d.Collinear {
+		fmt.Fprintf(&sb, "\nwarning: column %d has variance inflation factor %.2f", j, d.VIF[j])
+	}
+	fmt.Fprintf(&sb, "\n%d flagged observations", len(d.Flagged))
+	for _, f := range d.Flagged {
+		fmt.Fprintf(&sb, "\n%5d: %s", f.Index, strings.Join(f.Reasons, ", "))
+	}
+	return sb.String()
+}
@@ -0,0 +1,142 @@
+package regression
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// a straight line with one far out observation that is off the line
+var (
+	outlierDesign = [][]float64{{1, 1}, {1, 2}, {1, 3}, {1, 4}, {1, 5}, {1, 6}, {1, 7}, {1, 8}, {1, 9}, {1, 20}}
+	outlierResp   = []float64{2.3, 4.1, 5.8, 8.4, 9.9, 12.2, 13.8, 16.1, 18.3, 30.0}
+	outlierCoefs  = []float64{2.471238095238088, 1.4798095238095248}
+)
+
+func TestDiagnose(t *testing.T) {
+	d, err := Diagnose(outlierDesign, outlierResp, outlierCoefs)
+	if err != nil {
+		t.Fatalf("error calling Diagnose(outlierDesign, outlierResp, outlierCoefs): %s", err)
+	}
+	if round4(d.Leverage[0]) != 0.2152 || round4(d.Leverage[9]) != 0.7943 {
+		t.Fatalf("Diagnose leverage[0], [9] = %f, %f; want 0.2152, 0.7943", d.Leverage[0], d.Leverage[9])
+	}
+	if round4(d.Studentized[0]) != -1.172 || round4(d.Studentized[9]) != -19.0844 {
+		t.Fatalf("Diagnose studentized[0], [9] = %f, %f; want -1.172, -19.0844", d.Studentized[0], d.Studentized[9])
+	}
+	if round4(d.CooksDistance[0]) != 0.18 || round4(d.CooksDistance[9]) != 15.1532 {
+		t.Fatalf("Diagnose cook's distance[0], [9] = %f, %f; want 0.18, 15.1532", d.CooksDistance[0], d.CooksDistance[9])
+	}
+	if round4(d.DurbinWatson) != 1.1447 {
+		t.Fatalf("Diagnose Durbin-Watson = %f; want 1.1447", d.DurbinWatson)
+	}
+	bp := d.BreuschPagan
+	if round4(bp.Statistic) != 2.6941 || bp.DF != 1 || round4(bp.PValue) != 0.1007 {
+		t.Fatalf("Diagnose Breusch-Pagan = %+v; want {2.6941 1 0.1007}", bp)
+	}
+	if d.Heteroskedastic() {
+		t.Fatalf("Diagnose Heteroskedastic() = true; want false")
+	}
+	if !math.IsNaN(d.VIF[0]) || d.VIF[1] != 1.0 || d.Collinear != nil {
+		t.Fatalf("Diagnose VIF = %v, collinear %v; want [NaN 1], []", d.VIF, d.Collinear)
+	}
+	if len(d.Flagged) != 1 || d.Flagged[0].Index != 9 || len(d.Flagged[0].Reasons) != 3 {
+		t.Fatalf("Diagnose flagged = %+v; want only observation 9 for 3 reasons", d.Flagged)
+	}
+
+	out := d.String()
+	for _, line := range []string{"Durbin-Watson: 1.1447", "1 flagged observations", "    9: leverage 0.794 > 0.400"} {
+		if !strings.Contains(out, line) {
+			t.Fatalf("Diagnostics.String() = %q; want it to contain %q", out, line)
+		}
+	}
+}
+
+func TestDiagnoseVIF(t *testing.T) {
+	x := [][]float64{
+		{1, 1, 2.1}, {1, 2, 3.9}, {1, 3, 6.5}, {1, 4, 7.7},
+		{1, 5, 10.2}, {1, 6, 12.1}, {1, 7, 13.8}, {1, 8, 16.3},
+	}
+	y := []float64{3.2, 5.8, 9.9, 11.5, 15.3, 18.0, 20.9, 24.4}
+	d, err := Diagnose(x, y, []float64{0, 1, 1})
+	if err != nil {
+		t.Fatalf("error calling Diagnose(x, y, beta): %s", err)
+	}
+	// with two predictors both VIFs are 1 / (1 - r^2)
+	if round4(d.VIF[1]) != 341.3662 || round4(d.VIF[2]) != 341.3662 {
+		t.Fatalf("Diagnose VIF = %v; want [NaN 341.3662 341.3662]", d.VIF)
+	}
+	if len(d.Collinear) != 2 || d.Collinear[0] != 1 || d.Collinear[1] != 2 {
+		t.Fatalf("Diagnose collinear = %v; want [1 2]", d.Collinear)
+	}
+}
+
+func TestDiagnoseFullLeverage(t *testing.T) {
+	// the last column is a dummy picking out observation 0 alone,
+	// so the fit always passes through it
+	x := make([][]float64, 8)
+	for i := range x {
+		x[i] = []float64{1, float64(i + 1), 0}
+	}
+	x[0][2] = 1
+	d, err := Diagnose(x, outlierResp[:8], []float64{0.5, 2, 0})
+	if err != nil {
+		t.Fatalf("error calling Diagnose(x, y, beta): %s", err)
+	}
+	if round4(d.Leverage[0]) != 1.0 {
+		t.Fatalf("Diagnose leverage[0] = %f; want 1", d.Leverage[0])
+	}
+	if !math.IsNaN(d.Studentized[0]) || !math.IsNaN(d.CooksDistance[0]) {
+		t.Fatalf("Diagnose studentized[0], cook's distance[0] = %f, %f; want NaN, NaN",
+			d.Studentized[0], d.CooksDistance[0])
+	}
+	for i := 1; i < len(x); i++ {
+		if math.IsNaN(d.Studentized[i]) || math.IsInf(d.Studentized[i], 0) || math.IsNaN(d.CooksDistance[i]) {
+			t.Fatalf("Diagnose studentized[%d], cook's distance[%d] = %f, %f; want finite values",
+				i, i, d.Studentized[i], d.CooksDistance[i])
+		}
+	}
+}
+
+func TestDiagnoseHeteroskedastic(t *testing.T) {
+	// errors of alternating sign growing with x, so the squared
+	// residuals are almost a function of x and the p-value is tiny
+	x := make([][]float64, 200)
+	y := make([]float64, len(x))
+	for i := range x {
+		xi := float64(i + 1)
+		x[i] = []float64{1, xi}
+		y[i] = xi + math.Pow(-1, float64(i))*xi*xi/100
+	}
+	d, err := Diagnose(x, y, []float64{0, 1})
+	if err != nil {
+		t.Fatalf("error calling Diagnose(x, y, beta): %s", err)
+	}
+	bp := d.BreuschPagan
+	want := math.Erfc(math.Sqrt(bp.Statistic / 2))
+	if bp.Statistic < 100 || bp.PValue <= 0 || math.Abs(bp.PValue-want) > 1e-10*want {
+		t.Fatalf("Diagnose Breusch-Pagan = %+v; want a p-value of %g", bp, want)
+	}
+	if !d.Heteroskedastic() {
+		t.Fatalf("Diagnose Heteroskedastic() = false; want true")
+	}
+}
+
+func TestDiagnoseErrors(t *testing.T) {
+	if _, err := Diagnose(outlierDesign, outlierResp[1:], outlierCoefs); err == nil {
+		t.Fatalf("Diagnose with short y should raise error: vectors are of unequal size")
+	}
+	if _, err := Diagnose(outlierDesign, outlierResp, outlierCoefs[:1]); err == nil {
+		t.Fatalf("Diagnose with short beta should raise error: incompatible shapes")
+	}
+	if _, err := Diagnose(outlierDesign[:2], outlierResp[:2], outlierCoefs); err == nil {
+		t.Fatalf("Diagnose with 2 observations should raise error: need at least 2 more observations than coefficients")
+	}
+	if _, err := Diagnose([][]float64{{}, {}, {}}, resp[:3], []float64{}); err == nil {
+		t.Fatalf("Diagnose with no columns should raise error: design matrix has no columns")
+	}
+	collinear := [][]float64{{1, 1, 2}, {1, 2, 4}, {1, 3, 6}, {1, 4, 8}, {1, 5, 10}, {1, 6, 12}}
+	if _, err := Diagnose(collinear, resp, []float64{0, 1, 0}); err == nil {
+		t.Fatalf("Diagnose with collinear columns should raise error: design matrix is rank deficient")
+	}
+}
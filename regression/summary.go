@@ -0,0 +1,257 @@
+// Package regression provides inference and residual diagnostics for
+// fitted linear models, shared by the simple and multiple linear
+// regression chapters.  Models are described the way those chapters
+// build them: a design matrix x whose first column is 1 for the
+// intercept, a response y and coefficients beta.
+package regression
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dcooper46/go-ds-from-scratch/distributions"
+	"github.com/dcooper46/go-ds-from-scratch/utils"
+)
+
+// Coefficient holds the inference for one coefficient of a linear model
+type Coefficient struct {
+	Name     string
+	Estimate float64
+	StdErr   float64
+	T        float64
+	PValue   float64
+	// Lower and Upper bound the 95% confidence interval
+	Lower float64
+	Upper float64
+}
+
+// Summary describes a fitted linear model like R's summary(lm)
+type Summary struct {
+	Coefficients []Coefficient
+	Residuals    []float64
+	// ResidualStdErr estimates the standard deviation of the errors
+	ResidualStdErr float64
+	// DF is the residual degrees of freedom, n - p
+	DF          int
+	RSquared    float64
+	AdjRSquared float64
+	// FStatistic tests all coefficients except the intercept against zero
+	FStatistic float64
+	FDF        int
+	FPValue    float64
+	// LogLikelihood, AIC and BIC assume normal errors and count
+	// the error variance as a parameter, as R does
+	LogLikelihood float64
+	AIC           float64
+	BIC           float64
+
+	// covUnscaled is (X'X)^-1, the coefficient covariance over sigma^2
+	covUnscaled *utils.Matrix
+}
+
+// hasIntercept reports whether the first column of x is all ones
+func hasIntercept(x [][]float64) bool {
+	for _, row := range x {
+		if row[0] != 1.0 {
+			return false
+		}
+	}
+	return true
+}
+
+// xtxInverse returns (X'X)^-1 for a full rank design matrix
+func xtxInverse(xMat *utils.Matrix) (*utils.Matrix, error) {
+	xtx, err := xMat.T().Mul(xMat)
+	if err != nil {
+		return nil, err
+	}
+	chol, err := utils.NewCholesky(xtx)
+	if err != nil {
+		return nil, fmt.Errorf("design matrix is rank deficient: %s", err)
+	}
+	return chol.Inverse()
+}
+
+// residuals returns y - X*beta
+func residuals(xMat *utils.Matrix, y, beta []float64) ([]float64, error) {
+	fitted, err := xMat.MulVec(beta)
+	if err != nil {
+		return nil, err
+	}
+	return utils.VectorSub(y, fitted)
+}
+
+// Summarize computes standard errors, t statistics, p-values and 95%
+// confidence intervals for coefficients beta fit by least squares, along
+// with measures of fit.  Names label the coefficients when printing;
+// when nil the intercept is "(Intercept)" and the others "x1", "x2", ...
+func Summarize(x [][]float64, y, beta []float64, names []string) (*Summary, error) {
+	xMat, err := utils.FromRows(x)
+	if err != nil {
+		return nil, err
+	}
+	n, p := xMat.Shape()
+	if len(y) != n {
+		return nil, fmt.Errorf("vectors are of unequal size: %d != %d", len(y), n)
+	}
+	if p == 0 {
+		return nil, fmt.Errorf("design matrix has no columns")
+	}
+	if len(beta) != p {
+		return nil, fmt.Errorf("incompatible shapes: (%d,%d), (%d,1)", n, p, len(beta))
+	}
+	if n <= p {
+		return nil, fmt.Errorf("need more observations than coefficients, got %d and %d", n, p)
+	}
+	intercept := hasIntercept(x)
+	if names == nil {
+		names = make([]string, p)
+		for j := range names {
+			if j == 0 && intercept {
+				names[j] = "(Intercept)"
+			} else if intercept {
+				names[j] = fmt.Sprintf("x%d", j)
+			} else {
+				names[j] = fmt.Sprintf("x%d", j+1)
+			}
+		}
+	}
+	if len(names) != p {
+		return nil, fmt.Errorf("got %d names for %d coefficients", len(names), p)
+	}
+
+	cov, err := xtxInverse(xMat)
+	if err != nil {
+		return nil, err
+	}
+	res, err := residuals(xMat, y, beta)
+	if err != nil {
+		return nil, err
+	}
+	rss, _ := utils.SumOfSquares(res)
+	df := n - p
+	sigma2 := rss / float64(df)
+
+	tdist := distributions.StudentT{Nu: float64(df)}
+	q := tdist.Quantile(0.975)
+	coefs := make([]Coefficient, p)
+	for j := range coefs {
+		se := math.Sqrt(sigma2 * cov.At(j, j))
+		t := beta[j] / se
+		coefs[j] = Coefficient{
+			Name:     names[j],
+			Estimate: beta[j],
+			StdErr:   se,
+			T:        t,
+			PValue:   2.0 * tdist.CDF(-math.Abs(t)),
+			Lower:    beta[j] - q*se,
+			Upper:    beta[j] + q*se,
+		}
+	}
+
+	// without an intercept R^2 compares against the model y = 0
+	tss, _ := utils.SumOfSquares(y)
+	dfModel, dfTotal := p, n
+	if intercept {
+		tss, _ = utils.TotalSumOfSquares(y)
+		dfModel, dfTotal = p-1, n-1
+	}
+	s := &Summary{
+		Coefficients:   coefs,
+		Residuals:      res,
+		ResidualStdErr: math.Sqrt(sigma2),
+		DF:             df,
+		RSquared:       1.0 - rss/tss,
+		AdjRSquared:    1.0 - (rss/float64(df))/(tss/float64(dfTotal)),
+		FDF:            dfModel,
+		covUnscaled:    cov,
+	}
+	if dfModel > 0 {
+		d1, d2 := float64(dfModel), float64(df)
+		s.FStatistic = ((tss - rss) / d1) / sigma2
+		s.FPValue = distributions.RegIncBeta(d2/2.0, d1/2.0, d2/(d2+d1*s.FStatistic))
+	}
+	nf, k := float64(n), float64(p+1)
+	s.LogLikelihood = -nf / 2.0 * (math.Log(2.0*math.Pi) + math.Log(rss/nf) + 1.0)
+	s.AIC = -2.0*s.LogLikelihood + 2.0*k
+	s.BIC = -2.0*s.LogLikelihood + k*math.Log(nf)
+	return s, nil
+}
+
+// ConfInt returns confidence intervals for the coefficients
+// at any confidence level
+func (s *Summary) ConfInt(level float64) ([][2]float64, error) {
+	if !(level > 0.0 && level < 1.0) {
+		return nil, fmt.Errorf("confidence level must be in (0, 1), got %f", level)
+	}
+	q := distributions.StudentT{Nu: float64(s.DF)}.Quantile((1.0 + level) / 2.0)
+	intervals := make([][2]float64, len(s.Coefficients))
+	for j, c := range s.Coefficients {
+		intervals[j] = [2]float64{c.Estimate - q*c.StdErr, c.Estimate + q*c.StdErr}
+	}
+	return intervals, nil
+}
+
+// Covariance returns the estimated covariance matrix of the coefficients
+func (s *Summary) Covariance() *utils.Matrix {
+	return s.covUnscaled.Scale(s.ResidualStdErr * s.ResidualStdErr)
+}
+
+// formatP formats a p-value the way R does, flooring at machine precision
+func formatP(p float64) string {
+	if p < 2.2e-16 {
+		return "<2e-16"
+	}
+	return fmt.Sprintf("%.3g", p)
+}
+
+// stars gives R's significance codes for a p-value
+func stars(p float64) string {
+	switch {
+	case p < 0.001:
+		return "***"
+	case p < 0.01:
+		return "**"
+	case p < 0.05:
+		return "*"
+	case p < 0.1:
+		return "."
+	}
+	return ""
+}
+
+// String formats the summary like R's summary(lm), with the
+// confidence intervals and information criteria added
+func (s *Summary) String() string {
+	var sb strings.Builder
+	q, _ := utils.Quantiles(s.Residuals, []float64{0.0, 0.25, 0.5, 0.75, 1.0}, utils.Linear)
+	sb.WriteString("Residuals:\n")
+	fmt.Fprintf(&sb, "%10s %10s %10s %10s %10s\n", "Min", "1Q", "Median", "3Q", "Max")
+	fmt.Fprintf(&sb, "%10.4f %10.4f %10.4f %10.4f %10.4f\n\n", q[0], q[1], q[2], q[3], q[4])
+
+	width := len("(Intercept)")
+	for _, c := range s.Coefficients {
+		width = max(width, len(c.Name))
+	}
+	sb.WriteString("Coefficients:\n")
+	fmt.Fprintf(&sb, "%-*s %10s %10s %8s %9s     %10s %10s\n",
+		width, "", "Estimate", "Std. Error", "t value", "Pr(>|t|)", "2.5 %", "97.5 %")
+	for _, c := range s.Coefficients {
+		fmt.Fprintf(&sb, "%-*s %10.5f %10.5f %8.3f %9s %-3s %10.5f %10.5f\n",
+			width, c.Name, c.Estimate, c.StdErr, c.T, formatP(c.PValue), stars(c.PValue), c.Lower, c.Upper)
+	}
+	sb.WriteString("---\nSignif. codes:  0 '***' 0.001 '**' 0.01 '*' 0.05 '.' 0.1 ' ' 1\n\n")
+
+	fmt.Fprintf(&sb, "Residual standard error: %.4g on %d degrees of freedom\n", s.ResidualStdErr, s.DF)
+	fmt.Fprintf(&sb, "Multiple R-squared:  %.4g,\tAdjusted R-squared:  %.4g\n", s.RSquared, s.AdjRSquared)
+	if s.FDF > 0 {
+		p := formatP(s.FPValue)
+		if s.FPValue < 2.2e-16 {
+			p = "< 2.2e-16"
+		}
+		fmt.Fprintf(&sb, "F-statistic: %.4g on %d and %d DF,  p-value: %s\n", s.FStatistic, s.FDF, s.DF, p)
+	}
+	fmt.Fprintf(&sb, "Log-likelihood: %.2f,\tAIC: %.2f,\tBIC: %.2f", s.LogLikelihood, s.AIC, s.BIC)
+	return sb.String()
+}
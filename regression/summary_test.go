@@ -0,0 +1,93 @@
+package regression
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func round4(x float64) float64 {
+	return math.Round(x*10000) / 10000
+}
+
+var (
+	design = [][]float64{{1, 1}, {1, 2}, {1, 3}, {1, 4}, {1, 5}, {1, 6}}
+	resp   = []float64{2.1, 3.9, 6.2, 7.8, 10.1, 11.6}
+	coefs  = []float64{0.18, 1.9342857142857144}
+)
+
+func TestSummarize(t *testing.T) {
+	s, err := Summarize(design, resp, coefs, nil)
+	if err != nil {
+		t.Fatalf("error calling Summarize(design, resp, coefs): %s", err)
+	}
+	want := []Coefficient{
+		{Name: "(Intercept)", Estimate: 0.18, StdErr: 0.1972, T: 0.9129},
+		{Name: "x1", Estimate: 1.9343, StdErr: 0.0506, T: 38.2053},
+	}
+	for j, w := range want {
+		c := s.Coefficients[j]
+		if c.Name != w.Name || round4(c.Estimate) != w.Estimate || round4(c.StdErr) != w.StdErr || round4(c.T) != w.T {
+			t.Fatalf("Summarize coefficient %d = %+v; want %+v", j, c, w)
+		}
+	}
+	if p := s.Coefficients[0].PValue; round4(p) != 0.4129 {
+		t.Fatalf("Summarize intercept p-value = %f; want 0.4129", p)
+	}
+	if s.DF != 4 || round4(s.ResidualStdErr) != 0.2118 {
+		t.Fatalf("Summarize residual std err = %f on %d df; want 0.2118 on 4", s.ResidualStdErr, s.DF)
+	}
+	if round4(s.RSquared) != 0.9973 || round4(s.AdjRSquared) != 0.9966 {
+		t.Fatalf("Summarize R-squared = %f, adjusted %f; want 0.9973, 0.9966", s.RSquared, s.AdjRSquared)
+	}
+	if round4(s.FStatistic) != 1459.6465 || s.FDF != 1 {
+		t.Fatalf("Summarize F = %f on %d df; want 1459.6465 on 1", s.FStatistic, s.FDF)
+	}
+	if round4(s.LogLikelihood) != 2.0156 || round4(s.AIC) != 1.9688 || round4(s.BIC) != 1.3441 {
+		t.Fatalf("Summarize logLik, AIC, BIC = %f, %f, %f; want 2.0156, 1.9688, 1.3441",
+			s.LogLikelihood, s.AIC, s.BIC)
+	}
+
+	// the stored 95% interval agrees with ConfInt and the
+	// F statistic of one slope is the square of its t statistic
+	ci, err := s.ConfInt(0.95)
+	if err != nil {
+		t.Fatalf("error calling ConfInt(0.95): %s", err)
+	}
+	if ci[1][0] != s.Coefficients[1].Lower || ci[1][1] != s.Coefficients[1].Upper {
+		t.Fatalf("ConfInt(0.95)[1] = %v; want [%f %f]", ci[1], s.Coefficients[1].Lower, s.Coefficients[1].Upper)
+	}
+	if round4(s.Coefficients[1].T*s.Coefficients[1].T) != round4(s.FStatistic) {
+		t.Fatalf("slope t^2 = %f; want F = %f", s.Coefficients[1].T*s.Coefficients[1].T, s.FStatistic)
+	}
+	if cov := s.Covariance(); round4(math.Sqrt(cov.At(1, 1))) != 0.0506 {
+		t.Fatalf("Covariance()[1][1] = %f; want 0.0506^2", cov.At(1, 1))
+	}
+
+	out := s.String()
+	for _, line := range []string{"(Intercept)", "x1", "Residual standard error: 0.2118 on 4 degrees of freedom", "AIC: 1.97"} {
+		if !strings.Contains(out, line) {
+			t.Fatalf("Summary.String() = %q; want it to contain %q", out, line)
+		}
+	}
+}
+
+func TestSummarizeErrors(t *testing.T) {
+	if _, err := Summarize(design, resp[1:], coefs, nil); err == nil {
+		t.Fatalf("Summarize with short y should raise error: vectors are of unequal size")
+	}
+	if _, err := Summarize(design, resp, coefs[:1], nil); err == nil {
+		t.Fatalf("Summarize with short beta should raise error: incompatible shapes")
+	}
+	if _, err := Summarize(design, resp, coefs, []string{"a"}); err == nil {
+		t.Fatalf("Summarize with 1 name should raise error: got 1 names for 2 coefficients")
+	}
+	empty := [][]float64{{}, {}, {}}
+	if _, err := Summarize(empty, resp[:3], []float64{}, nil); err == nil {
+		t.Fatalf("Summarize with no columns should raise error: design matrix has no columns")
+	}
+	collinear := [][]float64{{1, 1, 2}, {1, 2, 4}, {1, 3, 6}, {1, 4, 8}, {1, 5, 10}, {1, 6, 12}}
+	if _, err := Summarize(collinear, resp, []float64{0, 1, 0}, nil); err == nil {
+		t.Fatalf("Summarize with collinear columns should raise error: design matrix is rank deficient")
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"log"
 )
 
@@ -33,11 +34,26 @@ var dailyMinutes []float64 = []float64{
 }
 
 func main() {
-	alpha, beta := LeastSquares(numFriends, dailyMinutes)
+	alpha, beta, err := LeastSquares(numFriends, dailyMinutes)
+	if err != nil {
+		log.Fatalf("error fitting least squares: %s", err)
+	}
 
 	log.Printf("alpha: %f, beta: %f\n", alpha, beta)
 
 	rSqr := RSquared(alpha, beta, numFriends, dailyMinutes)
 
 	log.Printf("r-squared: %f", rSqr)
+
+	summary, err := Summarize(alpha, beta, numFriends, dailyMinutes)
+	if err != nil {
+		log.Fatalf("error summarizing fit: %s", err)
+	}
+	fmt.Println(summary)
+
+	diagnostics, err := Diagnose(alpha, beta, numFriends, dailyMinutes)
+	if err != nil {
+		log.Fatalf("error diagnosing fit: %s", err)
+	}
+	fmt.Println(diagnostics)
 }
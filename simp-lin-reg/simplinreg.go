@@ -1,8 +1,10 @@
 package main
 
 import (
+	"log"
 	"math"
 
+	"github.com/dcooper46/go-ds-from-scratch/regression"
 	"github.com/dcooper46/go-ds-from-scratch/utils"
 )
 
@@ -24,27 +26,60 @@ func SumOfSquaredErrors(alpha, beta float64, x, y []float64) (sse float64) {
 	return
 }
 
-// TotalSumOfSquares gives the unnormalized variace
-func TotalSumOfSquares(y []float64) (tss float64) {
-	muy := utils.Mean(y)
-	for _, yi := range y {
-		tss += math.Pow(yi-muy, 2)
-	}
-	return
-}
-
 // RSquared returns the coefficient of determination of a fit model
 // The coefficient of determination shows how much variance in the
 // dependent variable is explained by the model
 func RSquared(alpha, beta float64, x, y []float64) (rSqrd float64) {
-	rSqrd = 1.0 - SumOfSquaredErrors(alpha, beta, x, y)/TotalSumOfSquares(y)
+	yhat := make([]float64, len(x))
+	for i, xi := range x {
+		yhat[i] = Predict(alpha, beta, xi)
+	}
+	rSqrd, err := utils.RSquared(y, yhat)
+	if err != nil {
+		log.Fatalf("error computing r-squared: %s", err)
+	}
 	return
 }
 
 // LeastSquares fits a simple linear model using least squares estimates
 // of parameters using mle derivations, given x, y slices of float64
-func LeastSquares(x, y []float64) (alpha, beta float64) {
-	beta = utils.Correlation(x, y) * utils.StandardDeviation(y) / utils.StandardDeviation(x)
-	alpha = utils.Mean(y) - beta*utils.Mean(x)
-	return
+func LeastSquares(x, y []float64) (alpha, beta float64, err error) {
+	corr, err := utils.Correlation(x, y)
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	stdx, err := utils.StandardDeviation(x)
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	stdy, err := utils.StandardDeviation(y)
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	mux, _ := utils.Mean(x)
+	muy, _ := utils.Mean(y)
+	beta = corr * stdy / stdx
+	alpha = muy - beta*mux
+	return alpha, beta, nil
+}
+
+// designMatrix prepends an intercept column to x
+func designMatrix(x []float64) [][]float64 {
+	design := make([][]float64, len(x))
+	for i, xi := range x {
+		design[i] = []float64{1.0, xi}
+	}
+	return design
+}
+
+// Summarize describes the fit of alpha and beta to x and y like R's
+// summary(lm), with standard errors, p-values and measures of fit
+func Summarize(alpha, beta float64, x, y []float64) (*regression.Summary, error) {
+	return regression.Summarize(designMatrix(x), y, []float64{alpha, beta}, []string{"(Intercept)", "x"})
+}
+
+// Diagnose checks the residuals of the fit of alpha and beta to x and y
+// and flags influential observations
+func Diagnose(alpha, beta float64, x, y []float64) (*regression.Diagnostics, error) {
+	return regression.Diagnose(designMatrix(x), y, []float64{alpha, beta})
 }
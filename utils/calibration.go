@@ -0,0 +1,339 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// probabilityEpsilon bounds probabilities away from 0 and 1
+// so their logs and log-odds stay finite
+const probabilityEpsilon = 1e-15
+
+// checkProbabilities returns an error unless labels are 0 or 1, probs
+// lie in [0, 1], and both are non-empty and the same length
+func checkProbabilities(labels, probs []float64) error {
+	if err := checkPredictions(labels, probs); err != nil {
+		return err
+	}
+	for i, l := range labels {
+		if l != 0.0 && l != 1.0 {
+			return fmt.Errorf("labels must be 0 or 1, got %f", l)
+		}
+		if !(probs[i] >= 0.0 && probs[i] <= 1.0) {
+			return fmt.Errorf("probabilities must be in [0, 1], got %f", probs[i])
+		}
+	}
+	return nil
+}
+
+// checkBothClasses returns an error unless labels hold both 0 and 1,
+// which a calibrator needs to learn anything
+func checkBothClasses(labels []float64) error {
+	var positives int
+	for _, l := range labels {
+		if l == 1.0 {
+			positives++
+		}
+	}
+	if positives == 0 || positives == len(labels) {
+		return fmt.Errorf("need both classes, got %d positives of %d", positives, len(labels))
+	}
+	return nil
+}
+
+// clipProbability keeps p within probabilityEpsilon of 0 and 1
+func clipProbability(p float64) float64 {
+	return math.Min(math.Max(p, probabilityEpsilon), 1.0-probabilityEpsilon)
+}
+
+// LogLoss gives the average negative log likelihood of labels, 1 for the
+// positive class and 0 for the negative, under the predicted probabilities
+// of the positive class.  Probabilities are clipped to [1e-15, 1 - 1e-15]
+// so a confident mistake costs about 34.5 rather than infinity.
+func LogLoss(labels, probs []float64) (float64, error) {
+	if err := checkProbabilities(labels, probs); err != nil {
+		return 0.0, err
+	}
+	var sum float64
+	for i, l := range labels {
+		p := clipProbability(probs[i])
+		sum -= l*math.Log(p) + (1.0-l)*math.Log(1.0-p)
+	}
+	return sum / float64(len(labels)), nil
+}
+
+// BrierScore gives the mean squared difference between labels, 1 for the
+// positive class and 0 for the negative, and the predicted probabilities
+// of the positive class
+func BrierScore(labels, probs []float64) (float64, error) {
+	if err := checkProbabilities(labels, probs); err != nil {
+		return 0.0, err
+	}
+	return MeanSquaredError(labels, probs)
+}
+
+// CalibrationBin summarizes the records whose predicted probability fell
+// in [Lower, Upper), or [Lower, 1] for the last bin: their mean prediction
+// and the fraction that were positive.  A well calibrated classifier has
+// the two about equal.
+type CalibrationBin struct {
+	Lower            float64
+	Upper            float64
+	MeanPredicted    float64
+	FractionPositive float64
+	Count            int
+}
+
+// CalibrationCurve splits [0, 1] into bins of equal width and compares
+// the predicted probabilities in each with the observed fraction of
+// positive labels, the points of a reliability diagram.  Empty bins are
+// left out.
+func CalibrationCurve(labels, probs []float64, bins int) ([]CalibrationBin, error) {
+	if err := checkProbabilities(labels, probs); err != nil {
+		return nil, err
+	}
+	if bins < 1 {
+		return nil, fmt.Errorf("need at least 1 bin, got %d", bins)
+	}
+	edges := make([]float64, bins+1)
+	for i := range edges {
+		edges[i] = float64(i) / float64(bins)
+	}
+	sums := make([]CalibrationBin, bins)
+	for i, p := range probs {
+		b := binIndex(edges, p)
+		sums[b].MeanPredicted += p
+		sums[b].FractionPositive += labels[i]
+		sums[b].Count++
+	}
+
+	var curve []CalibrationBin
+	for i, b := range sums {
+		if b.Count == 0 {
+			continue
+		}
+		curve = append(curve, CalibrationBin{
+			Lower:            edges[i],
+			Upper:            edges[i+1],
+			MeanPredicted:    b.MeanPredicted / float64(b.Count),
+			FractionPositive: b.FractionPositive / float64(b.Count),
+			Count:            b.Count,
+		})
+	}
+	return curve, nil
+}
+
+// ExpectedCalibrationError gives the average gap between the mean
+// predicted probability and the fraction of positives across the bins
+// of CalibrationCurve, weighted by the number of records in each bin
+func ExpectedCalibrationError(labels, probs []float64, bins int) (float64, error) {
+	curve, err := CalibrationCurve(labels, probs, bins)
+	if err != nil {
+		return 0.0, err
+	}
+	var ece float64
+	for _, b := range curve {
+		ece += float64(b.Count) * math.Abs(b.MeanPredicted-b.FractionPositive)
+	}
+	return ece / float64(len(labels)), nil
+}
+
+// Calibrator maps a predicted probability to one that better
+// matches the observed frequency of the positive class
+type Calibrator interface {
+	Calibrate(p float64) float64
+}
+
+// CalibratedScorer wraps a scorer returning the probability of the
+// positive class so its predictions pass through the calibrator c
+func CalibratedScorer[X any](score func(X) float64, c Calibrator) func(X) float64 {
+	return func(x X) float64 {
+		return c.Calibrate(score(x))
+	}
+}
+
+// PlattScaler calibrates by a logistic regression on the log-odds of the
+// predicted probability.  A Slope below 1 pulls overconfident predictions
+// back towards 0.5 and the Intercept corrects a bias towards either class.
+type PlattScaler struct {
+	Slope     float64
+	Intercept float64
+}
+
+// Calibrate returns 1 / (1 + exp(-(Slope * logit(p) + Intercept)))
+func (c *PlattScaler) Calibrate(p float64) float64 {
+	p = clipProbability(p)
+	z := c.Slope*math.Log(p/(1.0-p)) + c.Intercept
+	return 1.0 / (1.0 + math.Exp(-z))
+}
+
+// plattLoss gives the negative log likelihood of targets t
+// for log-odds z, computed without overflow
+func plattLoss(z, t float64) float64 {
+	if z >= 0.0 {
+		return (1.0-t)*z + math.Log1p(math.Exp(-z))
+	}
+	return -t*z + math.Log1p(math.Exp(z))
+}
+
+// FitPlatt fits a PlattScaler to labels, 1 for the positive class and 0
+// for the negative, and the predicted probabilities of the positive
+// class.  As in Platt's method the labels are smoothed towards 0.5 to
+// avoid overfitting small samples, and the fit uses Newton's method with
+// a backtracking line search.
+func FitPlatt(labels, probs []float64) (*PlattScaler, error) {
+	if err := checkProbabilities(labels, probs); err != nil {
+		return nil, err
+	}
+	if err := checkBothClasses(labels); err != nil {
+		return nil, err
+	}
+	var positives float64
+	for _, l := range labels {
+		positives += l
+	}
+	negatives := float64(len(labels)) - positives
+	hi, lo := (positives+1.0)/(positives+2.0), 1.0/(negatives+2.0)
+
+	f := make([]float64, len(probs))
+	t := make([]float64, len(probs))
+	for i, p := range probs {
+		p = clipProbability(p)
+		f[i] = math.Log(p / (1.0 - p))
+		t[i] = lo
+		if labels[i] == 1.0 {
+			t[i] = hi
+		}
+	}
+	loss := func(slope, intercept float64) float64 {
+		var sum float64
+		for i := range f {
+			sum += plattLoss(slope*f[i]+intercept, t[i])
+		}
+		return sum
+	}
+
+	c := &PlattScaler{Slope: 0.0, Intercept: math.Log((positives + 1.0) / (negatives + 1.0))}
+	current := loss(c.Slope, c.Intercept)
+	for iter := 0; iter < 100; iter++ {
+		// gradient and hessian, with a small ridge so the hessian is invertible
+		var g1, g2, h11, h12, h22 float64
+		h11, h22 = 1e-12, 1e-12
+		for i := range f {
+			p := 1.0 / (1.0 + math.Exp(-(c.Slope*f[i] + c.Intercept)))
+			d := p - t[i]
+			w := p * (1.0 - p)
+			g1 += d * f[i]
+			g2 += d
+			h11 += w * f[i] * f[i]
+			h12 += w * f[i]
+			h22 += w
+		}
+		if math.Abs(g1) < 1e-8 && math.Abs(g2) < 1e-8 {
+			break
+		}
+		det := h11*h22 - h12*h12
+		d1 := -(h22*g1 - h12*g2) / det
+		d2 := -(-h12*g1 + h11*g2) / det
+
+		step := 1.0
+		for ; step >= 1e-10; step /= 2.0 {
+			slope, intercept := c.Slope+step*d1, c.Intercept+step*d2
+			if next := loss(slope, intercept); next < current+1e-4*step*(g1*d1+g2*d2) {
+				c.Slope, c.Intercept, current = slope, intercept, next
+				break
+			}
+		}
+		if step < 1e-10 {
+			break
+		}
+	}
+	return c, nil
+}
+
+// IsotonicCalibrator calibrates by a non-decreasing step function fit to
+// the observed labels, interpolating linearly between its points.
+// Probabilities beyond the first or last point take that point's value.
+// The zero value has no points and leaves probabilities unchanged.
+type IsotonicCalibrator struct {
+	X []float64
+	Y []float64
+}
+
+// Calibrate returns the fitted probability for p
+func (c *IsotonicCalibrator) Calibrate(p float64) float64 {
+	if len(c.X) == 0 {
+		return p
+	}
+	last := len(c.X) - 1
+	if p <= c.X[0] {
+		return c.Y[0]
+	}
+	if p >= c.X[last] {
+		return c.Y[last]
+	}
+	i := sort.SearchFloat64s(c.X, p)
+	if c.X[i] == p {
+		return c.Y[i]
+	}
+	w := (p - c.X[i-1]) / (c.X[i] - c.X[i-1])
+	return c.Y[i-1] + w*(c.Y[i]-c.Y[i-1])
+}
+
+// isotonicBlock is a run of records pooled to share one fitted value
+type isotonicBlock struct {
+	lo, hi float64
+	sum    float64
+	count  float64
+}
+
+// FitIsotonic fits an IsotonicCalibrator to labels, 1 for the positive
+// class and 0 for the negative, and the predicted probabilities of the
+// positive class by pooling adjacent violators: records are sorted by
+// probability and neighbouring groups whose fraction of positives
+// decreases are merged until it never does.  Unlike Platt scaling it
+// assumes no shape beyond monotonicity, so it needs more data to fit well.
+func FitIsotonic(labels, probs []float64) (*IsotonicCalibrator, error) {
+	if err := checkProbabilities(labels, probs); err != nil {
+		return nil, err
+	}
+	if err := checkBothClasses(labels); err != nil {
+		return nil, err
+	}
+	order := make([]int, len(probs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return probs[order[a]] < probs[order[b]] })
+
+	var blocks []isotonicBlock
+	for _, i := range order {
+		p := probs[i]
+		// tied probabilities must get the same value, so they start pooled
+		if n := len(blocks); n > 0 && blocks[n-1].hi == p {
+			blocks[n-1].sum += labels[i]
+			blocks[n-1].count++
+		} else {
+			blocks = append(blocks, isotonicBlock{lo: p, hi: p, sum: labels[i], count: 1.0})
+		}
+		for n := len(blocks); n > 1 && blocks[n-2].sum/blocks[n-2].count > blocks[n-1].sum/blocks[n-1].count; n-- {
+			blocks[n-2].hi = blocks[n-1].hi
+			blocks[n-2].sum += blocks[n-1].sum
+			blocks[n-2].count += blocks[n-1].count
+			blocks = blocks[:n-1]
+		}
+	}
+
+	c := &IsotonicCalibrator{}
+	for _, b := range blocks {
+		v := b.sum / b.count
+		c.X = append(c.X, b.lo)
+		c.Y = append(c.Y, v)
+		if b.hi > b.lo {
+			c.X = append(c.X, b.hi)
+			c.Y = append(c.Y, v)
+		}
+	}
+	return c, nil
+}
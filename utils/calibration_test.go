@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestLogLossAndBrierScore(t *testing.T) {
+	// the examples from scikit-learn's log_loss and brier_score_loss
+	actual, err := LogLoss([]float64{1.0, 0.0, 0.0, 1.0}, []float64{0.9, 0.1, 0.2, 0.65})
+	if err != nil {
+		t.Fatalf("error calling LogLoss(labels, probs): %s", err)
+	}
+	if round4(actual) != 0.2162 {
+		t.Fatalf("LogLoss(labels, probs) = %f; want 0.2162", actual)
+	}
+	if actual, _ := LogLoss([]float64{1.0}, []float64{0.0}); round4(actual) != 34.5388 {
+		t.Fatalf("LogLoss([1], [0]) = %f; want 34.5388", actual)
+	}
+	actual, err = BrierScore([]float64{0.0, 1.0, 1.0, 0.0}, []float64{0.1, 0.9, 0.8, 0.3})
+	if err != nil {
+		t.Fatalf("error calling BrierScore(labels, probs): %s", err)
+	}
+	if round4(actual) != 0.0375 {
+		t.Fatalf("BrierScore(labels, probs) = %f; want 0.0375", actual)
+	}
+	if _, err := LogLoss([]float64{0.0, 2.0}, []float64{0.1, 0.9}); err == nil {
+		t.Fatalf("LogLoss with label 2 should raise error: labels must be 0 or 1")
+	}
+	if _, err := BrierScore([]float64{0.0, 1.0}, []float64{0.1, 1.2}); err == nil {
+		t.Fatalf("BrierScore with prob 1.2 should raise error: probabilities must be in [0, 1]")
+	}
+	if _, err := LogLoss([]float64{0.0, 1.0}, []float64{0.1}); err == nil {
+		t.Fatalf("LogLoss with short probs should raise error: vectors are of unequal size")
+	}
+}
+
+func TestCalibrationCurve(t *testing.T) {
+	// the example from scikit-learn's calibration_curve
+	labels := []float64{0.0, 0.0, 0.0, 0.0, 1.0, 1.0, 1.0, 1.0, 1.0}
+	probs := []float64{0.1, 0.2, 0.3, 0.4, 0.65, 0.7, 0.8, 0.9, 1.0}
+	curve, err := CalibrationCurve(labels, probs, 3)
+	if err != nil {
+		t.Fatalf("error calling CalibrationCurve(labels, probs, 3): %s", err)
+	}
+	want := []CalibrationBin{
+		{MeanPredicted: 0.2, FractionPositive: 0.0, Count: 3},
+		{MeanPredicted: 0.525, FractionPositive: 0.5, Count: 2},
+		{MeanPredicted: 0.85, FractionPositive: 1.0, Count: 4},
+	}
+	if len(curve) != len(want) {
+		t.Fatalf("CalibrationCurve(labels, probs, 3) = %+v; want %+v", curve, want)
+	}
+	for i, b := range curve {
+		rounded := CalibrationBin{
+			MeanPredicted:    round4(b.MeanPredicted),
+			FractionPositive: b.FractionPositive,
+			Count:            b.Count,
+		}
+		if rounded != want[i] {
+			t.Fatalf("CalibrationCurve(labels, probs, 3)[%d] = %+v; want %+v", i, b, want[i])
+		}
+	}
+	if curve[2].Upper != 1.0 {
+		t.Fatalf("CalibrationCurve(labels, probs, 3)[2].Upper = %f; want 1", curve[2].Upper)
+	}
+
+	actual, err := ExpectedCalibrationError(labels, probs, 3)
+	if err != nil {
+		t.Fatalf("error calling ExpectedCalibrationError(labels, probs, 3): %s", err)
+	}
+	if round4(actual) != 0.1389 {
+		t.Fatalf("ExpectedCalibrationError(labels, probs, 3) = %f; want 0.1389", actual)
+	}
+	// empty bins are left out
+	if sparse, _ := CalibrationCurve(labels, probs, 10); len(sparse) != 8 {
+		t.Fatalf("CalibrationCurve(labels, probs, 10) has %d bins; want 8", len(sparse))
+	}
+	if _, err := CalibrationCurve(labels, probs, 0); err == nil {
+		t.Fatalf("CalibrationCurve(labels, probs, 0) should raise error: need at least 1 bin")
+	}
+}
+
+// overconfident predictions that are right only
+// a little more often than chance
+var (
+	overconfidentProbs  = []float64{0.01, 0.02, 0.99, 0.98, 0.95, 0.05, 0.9, 0.1}
+	overconfidentLabels = []float64{0.0, 1.0, 1.0, 0.0, 1.0, 0.0, 1.0, 1.0}
+)
+
+func TestFitPlatt(t *testing.T) {
+	c, err := FitPlatt(overconfidentLabels, overconfidentProbs)
+	if err != nil {
+		t.Fatalf("error calling FitPlatt(labels, probs): %s", err)
+	}
+	if round4(c.Slope) != 0.1028 || round4(c.Intercept) != 0.4649 {
+		t.Fatalf("FitPlatt(labels, probs) = %+v; want {Slope: 0.1028 Intercept: 0.4649}", c)
+	}
+	if actual := c.Calibrate(0.99); round4(actual) != 0.7186 {
+		t.Fatalf("Calibrate(0.99) = %f; want 0.7186", actual)
+	}
+
+	calibrated := make([]float64, len(overconfidentProbs))
+	for i, p := range overconfidentProbs {
+		calibrated[i] = c.Calibrate(p)
+	}
+	before, _ := LogLoss(overconfidentLabels, overconfidentProbs)
+	after, _ := LogLoss(overconfidentLabels, calibrated)
+	if round4(before) != 1.2943 || round4(after) != 0.6304 {
+		t.Fatalf("LogLoss before, after Platt scaling = %f, %f; want 1.2943, 0.6304", before, after)
+	}
+	if _, err := FitPlatt([]float64{0.0, 0.0}, []float64{0.1, 0.9}); err == nil {
+		t.Fatalf("FitPlatt with only negatives should raise error: need both classes")
+	}
+	if _, err := FitPlatt([]float64{0.0}, []float64{0.1, 0.9}); err == nil {
+		t.Fatalf("FitPlatt with short labels should raise error: vectors are of unequal size")
+	}
+}
+
+func TestFitIsotonic(t *testing.T) {
+	probs := []float64{0.4, 0.1, 0.6, 0.2, 0.5, 0.3}
+	labels := []float64{1.0, 0.0, 0.0, 1.0, 1.0, 0.0}
+	c, err := FitIsotonic(labels, probs)
+	if err != nil {
+		t.Fatalf("error calling FitIsotonic(labels, probs): %s", err)
+	}
+	if !slices.Equal(c.X, []float64{0.1, 0.2, 0.3, 0.4, 0.6}) || !slices.Equal(c.Y, []float64{0.0, 0.5, 0.5, 2.0 / 3.0, 2.0 / 3.0}) {
+		t.Fatalf("FitIsotonic(labels, probs) = %+v; want X [0.1 0.2 0.3 0.4 0.6], Y [0 0.5 0.5 0.667 0.667]", c)
+	}
+	for _, test := range []struct{ p, want float64 }{
+		{0.05, 0.0}, {0.15, 0.25}, {0.25, 0.5}, {0.35, 0.5833}, {0.5, 0.6667}, {0.9, 0.6667},
+	} {
+		if actual := c.Calibrate(test.p); round4(actual) != test.want {
+			t.Fatalf("Calibrate(%v) = %f; want %v", test.p, actual, test.want)
+		}
+	}
+
+	// tied probabilities are pooled together
+	tied, _ := FitIsotonic([]float64{1.0, 1.0, 0.0}, []float64{0.8, 0.2, 0.2})
+	if !slices.Equal(tied.X, []float64{0.2, 0.8}) || !slices.Equal(tied.Y, []float64{0.5, 1.0}) {
+		t.Fatalf("FitIsotonic with tied probs = %+v; want X [0.2 0.8], Y [0.5 1]", tied)
+	}
+	var empty IsotonicCalibrator
+	if actual := empty.Calibrate(0.3); actual != 0.3 {
+		t.Fatalf("IsotonicCalibrator{}.Calibrate(0.3) = %f; want 0.3", actual)
+	}
+	if _, err := FitIsotonic([]float64{0.0, 1.0}, []float64{0.1, math.NaN()}); err == nil {
+		t.Fatalf("FitIsotonic with NaN prob should raise error: probabilities must be in [0, 1]")
+	}
+}
+
+func TestCalibratedScorer(t *testing.T) {
+	c := &PlattScaler{Slope: 0.5, Intercept: 0.0}
+	score := func(x float64) float64 { return 1.0 / (1.0 + math.Exp(-x)) }
+	calibrated := CalibratedScorer(score, Calibrator(c))
+	// halving the slope halves the log-odds
+	if actual := calibrated(4.0); math.Abs(actual-score(2.0)) > 1e-12 {
+		t.Fatalf("CalibratedScorer(score, c)(4) = %f; want %f", actual, score(2.0))
+	}
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+)
+
+// Cholesky is the factorization A = L*L' of a symmetric
+// positive definite matrix, where L is lower triangular
+type Cholesky struct {
+	l *Matrix
+}
+
+// NewCholesky factorizes a symmetric positive definite matrix.
+// The input matrix is not modified.
+func NewCholesky(a *Matrix) (*Cholesky, error) {
+	if err := a.checkSymmetric(); err != nil {
+		return nil, err
+	}
+	n, _ := a.Shape()
+
+	l := Zeros(n, n)
+	for j := 0; j < n; j++ {
+		lrowj := l.rowView(j)
+		d := a.At(j, j)
+		for k := 0; k < j; k++ {
+			d -= lrowj[k] * lrowj[k]
+		}
+		if d <= 0.0 || math.IsNaN(d) {
+			return nil, fmt.Errorf("matrix is not positive definite: leading minor %d", j+1)
+		}
+		lrowj[j] = math.Sqrt(d)
+		for i := j + 1; i < n; i++ {
+			lrowi := l.rowView(i)
+			s := a.At(i, j)
+			for k := 0; k < j; k++ {
+				s -= lrowi[k] * lrowj[k]
+			}
+			lrowi[j] = s / lrowj[j]
+		}
+	}
+	return &Cholesky{l: l}, nil
+}
+
+// L returns the lower triangular factor
+func (c *Cholesky) L() *Matrix {
+	return c.l.Copy()
+}
+
+// Det returns the determinant of the factorized matrix
+func (c *Cholesky) Det() float64 {
+	n, _ := c.l.Shape()
+	det := 1.0
+	for i := 0; i < n; i++ {
+		det *= c.l.data[i*n+i]
+	}
+	return det * det
+}
+
+// Solve finds x such that A*x = b
+func (c *Cholesky) Solve(b []float64) ([]float64, error) {
+	n, _ := c.l.Shape()
+	if len(b) != n {
+		return nil, fmt.Errorf(
+			"incompatible shapes: (%d,%d), (%d,)",
+			n, n, len(b))
+	}
+	// forward substitution with L
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		row := c.l.rowView(i)
+		x[i] = b[i]
+		for k := 0; k < i; k++ {
+			x[i] -= row[k] * x[k]
+		}
+		x[i] /= row[i]
+	}
+	// back substitution with L'
+	for i := n - 1; i >= 0; i-- {
+		for k := i + 1; k < n; k++ {
+			x[i] -= c.l.data[k*n+i] * x[k]
+		}
+		x[i] /= c.l.data[i*n+i]
+	}
+	return x, nil
+}
+
+// Inverse returns the inverse of the factorized matrix
+func (c *Cholesky) Inverse() (*Matrix, error) {
+	n, _ := c.l.Shape()
+	inv := Zeros(n, n)
+	for j := 0; j < n; j++ {
+		e := make([]float64, n)
+		e[j] = 1.0
+		col, err := c.Solve(e)
+		if err != nil {
+			return nil, err
+		}
+		for i, v := range col {
+			inv.data[i*n+j] = v
+		}
+	}
+	return inv, nil
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCholesky(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{4.0, 12.0, -16.0},
+		{12.0, 37.0, -43.0},
+		{-16.0, -43.0, 98.0},
+	})
+	chol, err := NewCholesky(A)
+	if err != nil {
+		t.Fatalf("error calling NewCholesky: %s", err)
+	}
+	expected := [][]float64{
+		{2.0, 0.0, 0.0},
+		{6.0, 1.0, 0.0},
+		{-8.0, 5.0, 3.0},
+	}
+	for i, row := range chol.L().ToRows() {
+		if !VectorsEqual(row, expected[i]) {
+			t.Fatalf("NewCholesky(A).L()[%d] = %v; want %v", i, row, expected[i])
+		}
+	}
+	if det := chol.Det(); math.Round(det*1e9)/1e9 != 36.0 {
+		t.Fatalf("NewCholesky(A).Det() = %f; want 36", det)
+	}
+
+	x, err := chol.Solve([]float64{0.0, 6.0, 39.0})
+	if err != nil {
+		t.Fatalf("error calling Solve: %s", err)
+	}
+	b, _ := A.MulVec(x)
+	for i, bi := range []float64{0.0, 6.0, 39.0} {
+		if math.Abs(b[i]-bi) > 1e-9 {
+			t.Fatalf("A * Solve([0, 6, 39]) = %v; want [0, 6, 39]", b)
+		}
+	}
+}
+
+func TestCholeskyNotPositiveDefinite(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{1.0, 2.0},
+		{2.0, 1.0},
+	})
+	if _, err := NewCholesky(A); err == nil {
+		t.Fatalf("NewCholesky([[1, 2], [2, 1]]) should raise error: matrix is not positive definite")
+	}
+	B, _ := FromRows([][]float64{
+		{1.0, 2.0},
+		{0.0, 1.0},
+	})
+	if _, err := NewCholesky(B); err == nil {
+		t.Fatalf("NewCholesky([[1, 2], [0, 1]]) should raise error: matrix is not symmetric")
+	}
+}
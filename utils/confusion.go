@@ -0,0 +1,213 @@
+package utils
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ConfusionMatrix counts the predictions of a classifier with any number
+// of classes, labelled by strings, ints or any other ordered type
+type ConfusionMatrix[L cmp.Ordered] struct {
+	// Labels holds every label seen in the truth or the predictions, sorted
+	Labels []L
+	// Counts[i][j] is the number of records of class Labels[i]
+	// predicted as Labels[j]
+	Counts [][]int
+}
+
+// ClassMetrics measures predictions of one class, or an average over classes.
+// Support is the number of records of the class.
+type ClassMetrics struct {
+	Precision float64
+	Recall    float64
+	F1        float64
+	Support   int
+}
+
+// NewConfusionMatrix counts true labels against predicted labels
+func NewConfusionMatrix[L cmp.Ordered](truth, pred []L) (*ConfusionMatrix[L], error) {
+	if len(truth) != len(pred) {
+		return nil, fmt.Errorf("vectors are of unequal size: %d != %d", len(truth), len(pred))
+	}
+	if len(truth) == 0 {
+		return nil, fmt.Errorf("need at least 1 values, got 0")
+	}
+	labels := append(slices.Clone(truth), pred...)
+	slices.Sort(labels)
+	labels = slices.Compact(labels)
+	counts := make([][]int, len(labels))
+	for i := range counts {
+		counts[i] = make([]int, len(labels))
+	}
+	for k := range truth {
+		i, _ := slices.BinarySearch(labels, truth[k])
+		j, _ := slices.BinarySearch(labels, pred[k])
+		counts[i][j]++
+	}
+	return &ConfusionMatrix[L]{Labels: labels, Counts: counts}, nil
+}
+
+// Total returns the number of records
+func (c *ConfusionMatrix[L]) Total() int {
+	var total int
+	for _, row := range c.Counts {
+		for _, n := range row {
+			total += n
+		}
+	}
+	return total
+}
+
+// Accuracy returns the share of records predicted correctly
+func (c *ConfusionMatrix[L]) Accuracy() float64 {
+	var correct int
+	for i := range c.Counts {
+		correct += c.Counts[i][i]
+	}
+	return float64(correct) / float64(c.Total())
+}
+
+// ratio divides, taking 0/0 as 0 as for a class that is never predicted
+func ratio(num, den float64) float64 {
+	if den == 0.0 {
+		return 0.0
+	}
+	return num / den
+}
+
+// f1 is the harmonic mean of precision and recall
+func f1(precision, recall float64) float64 {
+	return ratio(2.0*precision*recall, precision+recall)
+}
+
+// PerClass returns the precision, recall, F1 score and support of
+// each class in the order of Labels.  Precision is 0 for a class that
+// is never predicted, and recall is 0 for one that never occurs.
+func (c *ConfusionMatrix[L]) PerClass() []ClassMetrics {
+	metrics := make([]ClassMetrics, len(c.Labels))
+	for i := range c.Labels {
+		var actual, predicted int
+		for j := range c.Labels {
+			actual += c.Counts[i][j]
+			predicted += c.Counts[j][i]
+		}
+		tp := float64(c.Counts[i][i])
+		p, r := ratio(tp, float64(predicted)), ratio(tp, float64(actual))
+		metrics[i] = ClassMetrics{Precision: p, Recall: r, F1: f1(p, r), Support: actual}
+	}
+	return metrics
+}
+
+// Class returns the metrics of one class
+func (c *ConfusionMatrix[L]) Class(label L) (ClassMetrics, error) {
+	i, ok := slices.BinarySearch(c.Labels, label)
+	if !ok {
+		return ClassMetrics{}, fmt.Errorf("unknown label: %v", label)
+	}
+	return c.PerClass()[i], nil
+}
+
+// MacroAverage returns the unweighted mean of the per class metrics,
+// treating every class as equally important
+func (c *ConfusionMatrix[L]) MacroAverage() ClassMetrics {
+	avg := ClassMetrics{Support: c.Total()}
+	per := c.PerClass()
+	for _, m := range per {
+		avg.Precision += m.Precision / float64(len(per))
+		avg.Recall += m.Recall / float64(len(per))
+		avg.F1 += m.F1 / float64(len(per))
+	}
+	return avg
+}
+
+// WeightedAverage returns the mean of the per class metrics
+// weighted by the support of each class
+func (c *ConfusionMatrix[L]) WeightedAverage() ClassMetrics {
+	total := float64(c.Total())
+	avg := ClassMetrics{Support: c.Total()}
+	for _, m := range c.PerClass() {
+		w := float64(m.Support) / total
+		avg.Precision += w * m.Precision
+		avg.Recall += w * m.Recall
+		avg.F1 += w * m.F1
+	}
+	return avg
+}
+
+// MicroAverage returns the metrics of the pooled true positives, false
+// positives and false negatives of every class.  With one label per record
+// all three equal the accuracy.
+func (c *ConfusionMatrix[L]) MicroAverage() ClassMetrics {
+	acc := c.Accuracy()
+	return ClassMetrics{Precision: acc, Recall: acc, F1: acc, Support: c.Total()}
+}
+
+// Kappa returns Cohen's kappa, the agreement between the truth and the
+// predictions beyond what their class frequencies give by chance.
+// It is 1 for perfect predictions and near 0 for random ones.
+func (c *ConfusionMatrix[L]) Kappa() float64 {
+	total := float64(c.Total())
+	var chance float64
+	for i := range c.Labels {
+		var actual, predicted int
+		for j := range c.Labels {
+			actual += c.Counts[i][j]
+			predicted += c.Counts[j][i]
+		}
+		chance += float64(actual) * float64(predicted) / (total * total)
+	}
+	if chance == 1.0 {
+		return 1.0
+	}
+	return (c.Accuracy() - chance) / (1.0 - chance)
+}
+
+// String formats the matrix with true labels down the side
+// and predicted labels across the top
+func (c *ConfusionMatrix[L]) String() string {
+	names := make([]string, len(c.Labels))
+	width := len("true\\pred")
+	for i, l := range c.Labels {
+		names[i] = fmt.Sprint(l)
+		width = max(width, len(names[i]))
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%*s", width, "true\\pred")
+	for _, name := range names {
+		fmt.Fprintf(&sb, " %*s", width, name)
+	}
+	for i, row := range c.Counts {
+		fmt.Fprintf(&sb, "\n%*s", width, names[i])
+		for _, n := range row {
+			fmt.Fprintf(&sb, " %*d", width, n)
+		}
+	}
+	return sb.String()
+}
+
+// Report formats the per class and averaged metrics
+// like scikit-learn's classification report, with Cohen's kappa
+func (c *ConfusionMatrix[L]) Report() string {
+	width := len("weighted avg")
+	names := make([]string, len(c.Labels))
+	for i, l := range c.Labels {
+		names[i] = fmt.Sprint(l)
+		width = max(width, len(names[i]))
+	}
+	var sb strings.Builder
+	row := func(name string, m ClassMetrics) {
+		fmt.Fprintf(&sb, "%*s %9.2f %9.2f %9.2f %9d\n", width, name, m.Precision, m.Recall, m.F1, m.Support)
+	}
+	fmt.Fprintf(&sb, "%*s %9s %9s %9s %9s\n\n", width, "", "precision", "recall", "f1-score", "support")
+	for i, m := range c.PerClass() {
+		row(names[i], m)
+	}
+	fmt.Fprintf(&sb, "\n%*s %9s %9s %9.2f %9d\n", width, "accuracy", "", "", c.Accuracy(), c.Total())
+	row("micro avg", c.MicroAverage())
+	row("macro avg", c.MacroAverage())
+	row("weighted avg", c.WeightedAverage())
+	fmt.Fprintf(&sb, "\n%*s %9.2f", width, "kappa", c.Kappa())
+	return sb.String()
+}
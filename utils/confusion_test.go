@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func round2(x float64) float64 {
+	return math.Round(x*100) / 100
+}
+
+func TestConfusionMatrix(t *testing.T) {
+	// the example from scikit-learn's classification_report
+	truth := []int{0, 1, 2, 2, 2}
+	pred := []int{0, 0, 2, 2, 1}
+	c, err := NewConfusionMatrix(truth, pred)
+	if err != nil {
+		t.Fatalf("error calling NewConfusionMatrix(truth, pred): %s", err)
+	}
+	if !slices.Equal(c.Labels, []int{0, 1, 2}) || !slices.Equal(c.Counts[0], []int{1, 0, 0}) ||
+		!slices.Equal(c.Counts[1], []int{1, 0, 0}) || !slices.Equal(c.Counts[2], []int{0, 1, 2}) {
+		t.Fatalf("NewConfusionMatrix(truth, pred) = %v %v; want [0 1 2] [[1 0 0] [1 0 0] [0 1 2]]", c.Labels, c.Counts)
+	}
+	want := []ClassMetrics{
+		{Precision: 0.5, Recall: 1.0, F1: 0.67, Support: 1},
+		{Precision: 0.0, Recall: 0.0, F1: 0.0, Support: 1},
+		{Precision: 1.0, Recall: 0.67, F1: 0.8, Support: 3},
+	}
+	for i, m := range c.PerClass() {
+		rounded := ClassMetrics{round2(m.Precision), round2(m.Recall), round2(m.F1), m.Support}
+		if rounded != want[i] {
+			t.Fatalf("PerClass()[%d] = %+v; want %+v", i, rounded, want[i])
+		}
+	}
+	macro, weighted, micro := c.MacroAverage(), c.WeightedAverage(), c.MicroAverage()
+	if round2(macro.Precision) != 0.5 || round2(macro.Recall) != 0.56 || round2(macro.F1) != 0.49 {
+		t.Fatalf("MacroAverage() = %+v; want {0.5 0.56 0.49 5}", macro)
+	}
+	if round2(weighted.Precision) != 0.7 || round2(weighted.Recall) != 0.6 || round2(weighted.F1) != 0.61 {
+		t.Fatalf("WeightedAverage() = %+v; want {0.7 0.6 0.61 5}", weighted)
+	}
+	if micro.F1 != 0.6 || c.Accuracy() != 0.6 {
+		t.Fatalf("MicroAverage().F1, Accuracy() = %f, %f; want 0.6, 0.6", micro.F1, c.Accuracy())
+	}
+	if actual := c.Kappa(); math.Abs(actual-0.375) > 1e-12 {
+		t.Fatalf("Kappa() = %f; want 0.375", actual)
+	}
+	report := c.Report()
+	for _, line := range []string{"precision", "weighted avg      0.70      0.60      0.61         5", "kappa      0.38"} {
+		if !strings.Contains(report, line) {
+			t.Fatalf("Report() = %q; want it to contain %q", report, line)
+		}
+	}
+}
+
+func TestConfusionMatrixStrings(t *testing.T) {
+	truth := []string{"cat", "dog", "cat", "bird", "dog"}
+	pred := []string{"cat", "dog", "dog", "bird", "dog"}
+	c, err := NewConfusionMatrix(truth, pred)
+	if err != nil {
+		t.Fatalf("error calling NewConfusionMatrix(truth, pred): %s", err)
+	}
+	if !slices.Equal(c.Labels, []string{"bird", "cat", "dog"}) {
+		t.Fatalf("NewConfusionMatrix(truth, pred).Labels = %v; want [bird cat dog]", c.Labels)
+	}
+	dog, err := c.Class("dog")
+	if err != nil {
+		t.Fatalf("error calling Class(dog): %s", err)
+	}
+	if round2(dog.Precision) != 0.67 || dog.Recall != 1.0 || dog.Support != 2 {
+		t.Fatalf("Class(dog) = %+v; want {0.67 1 0.8 2}", dog)
+	}
+	if _, err := c.Class("fish"); err == nil {
+		t.Fatalf("Class(fish) should raise error: unknown label")
+	}
+	if !strings.Contains(c.String(), "true\\pred      bird       cat       dog") {
+		t.Fatalf("String() = %q; want a header of predicted labels", c.String())
+	}
+	if _, err := NewConfusionMatrix(truth, pred[1:]); err == nil {
+		t.Fatalf("NewConfusionMatrix with short pred should raise error: vectors are of unequal size")
+	}
+}
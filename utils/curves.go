@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ROCCurve traces the true and false positive rates of a classifier's
+// scores as the threshold for a positive prediction is lowered.
+// Point i predicts positive when the score is at least Thresholds[i];
+// the first point, at threshold +Inf, predicts nothing positive.
+type ROCCurve struct {
+	Thresholds []float64
+	FPR        []float64
+	TPR        []float64
+}
+
+// PRCurve traces the precision and recall of a classifier's scores as
+// the threshold for a positive prediction is lowered.  Point i predicts
+// positive when the score is at least Thresholds[i].
+type PRCurve struct {
+	Thresholds []float64
+	Precision  []float64
+	Recall     []float64
+}
+
+// thresholdCounts returns the distinct scores in decreasing order with
+// the number of true and false positives when predicting positive at or
+// above each.  Tied scores share a threshold, so they always move together.
+// Labels are 1 for the positive class and 0 for the negative.
+func thresholdCounts(labels, scores []float64) (thresholds, tp, fp []float64, err error) {
+	if len(labels) != len(scores) {
+		return nil, nil, nil, fmt.Errorf("vectors are of unequal size: %d != %d", len(labels), len(scores))
+	}
+	var positives int
+	for _, l := range labels {
+		if l != 0.0 && l != 1.0 {
+			return nil, nil, nil, fmt.Errorf("labels must be 0 or 1, got %f", l)
+		}
+		if l == 1.0 {
+			positives++
+		}
+	}
+	if positives == 0 || positives == len(labels) {
+		return nil, nil, nil, fmt.Errorf("need both classes, got %d positives of %d", positives, len(labels))
+	}
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+		if math.IsNaN(scores[i]) {
+			return nil, nil, nil, fmt.Errorf("scores must not be NaN")
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+
+	var tps, fps float64
+	for k, i := range order {
+		tps += labels[i]
+		fps += 1.0 - labels[i]
+		if k+1 < len(order) && scores[order[k+1]] == scores[i] {
+			continue
+		}
+		thresholds = append(thresholds, scores[i])
+		tp = append(tp, tps)
+		fp = append(fp, fps)
+	}
+	return thresholds, tp, fp, nil
+}
+
+// ROC computes the receiver operating characteristic curve from labels,
+// 1 for the positive class and 0 for the negative, and scores that are
+// higher for records more likely to be positive
+func ROC(labels, scores []float64) (*ROCCurve, error) {
+	thresholds, tp, fp, err := thresholdCounts(labels, scores)
+	if err != nil {
+		return nil, err
+	}
+	p, n := tp[len(tp)-1], fp[len(fp)-1]
+	c := &ROCCurve{
+		Thresholds: append([]float64{math.Inf(1)}, thresholds...),
+		FPR:        make([]float64, len(thresholds)+1),
+		TPR:        make([]float64, len(thresholds)+1),
+	}
+	for i := range thresholds {
+		c.FPR[i+1] = fp[i] / n
+		c.TPR[i+1] = tp[i] / p
+	}
+	return c, nil
+}
+
+// AUC returns the area under the curve by the trapezoidal rule, the
+// probability that a random positive record scores above a random
+// negative one, counting ties as half
+func (c *ROCCurve) AUC() float64 {
+	var area float64
+	for i := 1; i < len(c.FPR); i++ {
+		area += (c.FPR[i] - c.FPR[i-1]) * (c.TPR[i] + c.TPR[i-1]) / 2.0
+	}
+	return area
+}
+
+// YoudenThreshold returns the threshold maximizing Youden's J statistic,
+// TPR - FPR, along with J.  Of equally good thresholds the highest is chosen.
+func (c *ROCCurve) YoudenThreshold() (threshold, j float64) {
+	best := 0
+	for i := 1; i < len(c.Thresholds); i++ {
+		if c.TPR[i]-c.FPR[i] > c.TPR[best]-c.FPR[best] {
+			best = i
+		}
+	}
+	return c.Thresholds[best], c.TPR[best] - c.FPR[best]
+}
+
+// PrecisionRecall computes the precision-recall curve from labels,
+// 1 for the positive class and 0 for the negative, and scores that are
+// higher for records more likely to be positive
+func PrecisionRecall(labels, scores []float64) (*PRCurve, error) {
+	thresholds, tp, fp, err := thresholdCounts(labels, scores)
+	if err != nil {
+		return nil, err
+	}
+	p := tp[len(tp)-1]
+	c := &PRCurve{
+		Thresholds: thresholds,
+		Precision:  make([]float64, len(thresholds)),
+		Recall:     make([]float64, len(thresholds)),
+	}
+	for i := range thresholds {
+		c.Precision[i] = tp[i] / (tp[i] + fp[i])
+		c.Recall[i] = tp[i] / p
+	}
+	return c, nil
+}
+
+// AveragePrecision summarizes the curve as the precision at each
+// threshold weighted by the recall gained there, without interpolation
+func (c *PRCurve) AveragePrecision() float64 {
+	var ap, recall float64
+	for i, r := range c.Recall {
+		ap += (r - recall) * c.Precision[i]
+		recall = r
+	}
+	return ap
+}
+
+// ThresholdForPrecision returns the threshold with the highest recall
+// among those whose precision is at least target, along with that recall.
+// Of thresholds with equal recall the highest is chosen.
+func (c *PRCurve) ThresholdForPrecision(target float64) (threshold, recall float64, err error) {
+	found := false
+	for i, p := range c.Precision {
+		if p >= target && (!found || c.Recall[i] > recall) {
+			threshold, recall, found = c.Thresholds[i], c.Recall[i], true
+		}
+	}
+	if !found {
+		return 0.0, 0.0, fmt.Errorf("no threshold reaches precision %f", target)
+	}
+	return threshold, recall, nil
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// the example from scikit-learn's roc_curve and average_precision_score
+var (
+	curveLabels = []float64{0.0, 0.0, 1.0, 1.0}
+	curveScores = []float64{0.1, 0.4, 0.35, 0.8}
+)
+
+func TestROC(t *testing.T) {
+	c, err := ROC(curveLabels, curveScores)
+	if err != nil {
+		t.Fatalf("error calling ROC(labels, scores): %s", err)
+	}
+	if !slices.Equal(c.Thresholds, []float64{math.Inf(1), 0.8, 0.4, 0.35, 0.1}) ||
+		!slices.Equal(c.FPR, []float64{0.0, 0.0, 0.5, 0.5, 1.0}) ||
+		!slices.Equal(c.TPR, []float64{0.0, 0.5, 0.5, 1.0, 1.0}) {
+		t.Fatalf("ROC(labels, scores) = %+v; want thresholds [+Inf 0.8 0.4 0.35 0.1], "+
+			"fpr [0 0 0.5 0.5 1], tpr [0 0.5 0.5 1 1]", c)
+	}
+	if actual := c.AUC(); actual != 0.75 {
+		t.Fatalf("ROC(labels, scores).AUC() = %f; want 0.75", actual)
+	}
+	if threshold, j := c.YoudenThreshold(); threshold != 0.8 || j != 0.5 {
+		t.Fatalf("YoudenThreshold() = %f, %f; want 0.8, 0.5", threshold, j)
+	}
+
+	// a tie between a positive and a negative counts as half
+	tied, _ := ROC([]float64{0.0, 1.0, 0.0, 1.0}, []float64{0.5, 0.5, 0.2, 0.8})
+	if actual := tied.AUC(); actual != 0.875 {
+		t.Fatalf("ROC with tied scores AUC() = %f; want 0.875", actual)
+	}
+	if len(tied.Thresholds) != 4 {
+		t.Fatalf("ROC with tied scores thresholds = %v; want [+Inf 0.8 0.5 0.2]", tied.Thresholds)
+	}
+
+	if _, err := ROC([]float64{1.0, 1.0}, []float64{0.2, 0.3}); err == nil {
+		t.Fatalf("ROC with only positives should raise error: need both classes")
+	}
+	if _, err := ROC([]float64{0.0, 2.0}, []float64{0.2, 0.3}); err == nil {
+		t.Fatalf("ROC with label 2 should raise error: labels must be 0 or 1")
+	}
+	if _, err := ROC(curveLabels, curveScores[1:]); err == nil {
+		t.Fatalf("ROC with short scores should raise error: vectors are of unequal size")
+	}
+}
+
+func TestPrecisionRecall(t *testing.T) {
+	c, err := PrecisionRecall(curveLabels, curveScores)
+	if err != nil {
+		t.Fatalf("error calling PrecisionRecall(labels, scores): %s", err)
+	}
+	if !slices.Equal(c.Thresholds, []float64{0.8, 0.4, 0.35, 0.1}) ||
+		!slices.Equal(c.Precision, []float64{1.0, 0.5, 2.0 / 3.0, 0.5}) ||
+		!slices.Equal(c.Recall, []float64{0.5, 0.5, 1.0, 1.0}) {
+		t.Fatalf("PrecisionRecall(labels, scores) = %+v; want thresholds [0.8 0.4 0.35 0.1], "+
+			"precision [1 0.5 0.667 0.5], recall [0.5 0.5 1 1]", c)
+	}
+	if actual := c.AveragePrecision(); math.Abs(actual-5.0/6.0) > 1e-12 {
+		t.Fatalf("AveragePrecision() = %f; want 0.833333", actual)
+	}
+	if threshold, recall, _ := c.ThresholdForPrecision(0.6); threshold != 0.35 || recall != 1.0 {
+		t.Fatalf("ThresholdForPrecision(0.6) = %f, %f; want 0.35, 1", threshold, recall)
+	}
+	if threshold, recall, _ := c.ThresholdForPrecision(0.9); threshold != 0.8 || recall != 0.5 {
+		t.Fatalf("ThresholdForPrecision(0.9) = %f, %f; want 0.8, 0.5", threshold, recall)
+	}
+	if _, _, err := c.ThresholdForPrecision(1.1); err == nil {
+		t.Fatalf("ThresholdForPrecision(1.1) should raise error: no threshold reaches precision")
+	}
+}
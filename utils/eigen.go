@@ -0,0 +1,281 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// maxSweeps bounds the number of Jacobi sweeps before giving up
+const maxSweeps = 100
+
+// EigenSym is the eigendecomposition A = V*diag(values)*V' of a
+// real symmetric matrix.  Eigenvalues are sorted by decreasing magnitude
+// and the columns of V are the matching orthonormal eigenvectors.
+type EigenSym struct {
+	values  []float64
+	vectors *Matrix
+}
+
+// NewEigenSym computes the eigendecomposition of a symmetric matrix
+// using the cyclic Jacobi method.  The input matrix is not modified.
+func NewEigenSym(a *Matrix) (*EigenSym, error) {
+	if err := a.checkSymmetric(); err != nil {
+		return nil, err
+	}
+	n, _ := a.Shape()
+	w := a.Copy()
+	v := Identity(n)
+
+	var total float64
+	for _, x := range w.data {
+		total += x * x
+	}
+
+	converged := false
+	for sweep := 0; sweep < maxSweeps; sweep++ {
+		var off float64
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i != j {
+					off += w.data[i*n+j] * w.data[i*n+j]
+				}
+			}
+		}
+		if off <= epsilon*epsilon*total {
+			converged = true
+			break
+		}
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := w.data[p*n+q]
+				if apq == 0.0 {
+					continue
+				}
+				c, s := jacobiRotation(w.data[p*n+p], w.data[q*n+q], apq)
+				// A = J'AJ, rotating columns then rows p and q
+				for k := 0; k < n; k++ {
+					akp, akq := w.data[k*n+p], w.data[k*n+q]
+					w.data[k*n+p] = c*akp - s*akq
+					w.data[k*n+q] = s*akp + c*akq
+				}
+				for k := 0; k < n; k++ {
+					apk, aqk := w.data[p*n+k], w.data[q*n+k]
+					w.data[p*n+k] = c*apk - s*aqk
+					w.data[q*n+k] = s*apk + c*aqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := v.data[k*n+p], v.data[k*n+q]
+					v.data[k*n+p] = c*vkp - s*vkq
+					v.data[k*n+q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+	if !converged {
+		return nil, fmt.Errorf("eigendecomposition did not converge in %d sweeps", maxSweeps)
+	}
+
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = w.data[i*n+i]
+	}
+	order := sortedOrder(values)
+	return &EigenSym{
+		values:  permute(values, order),
+		vectors: permuteColumns(v, order),
+	}, nil
+}
+
+// jacobiRotation returns the cosine and sine of the rotation
+// that zeroes the off-diagonal element apq of a symmetric 2x2 block
+func jacobiRotation(app, aqq, apq float64) (c, s float64) {
+	theta := (aqq - app) / (2.0 * apq)
+	t := 1.0 / (math.Abs(theta) + math.Sqrt(theta*theta+1.0))
+	if theta < 0 {
+		t = -t
+	}
+	c = 1.0 / math.Sqrt(t*t+1.0)
+	return c, t * c
+}
+
+// sortedOrder returns the indices of values ordered by decreasing magnitude
+func sortedOrder(values []float64) []int {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return math.Abs(values[order[i]]) > math.Abs(values[order[j]])
+	})
+	return order
+}
+
+func permute(values []float64, order []int) []float64 {
+	permuted := make([]float64, len(values))
+	for i, o := range order {
+		permuted[i] = values[o]
+	}
+	return permuted
+}
+
+func permuteColumns(m *Matrix, order []int) *Matrix {
+	permuted := Zeros(m.rows, len(order))
+	for i := 0; i < m.rows; i++ {
+		row, prow := m.rowView(i), permuted.rowView(i)
+		for j, o := range order {
+			prow[j] = row[o]
+		}
+	}
+	return permuted
+}
+
+// Values returns the eigenvalues sorted by decreasing magnitude
+func (e *EigenSym) Values() []float64 {
+	values := make([]float64, len(e.values))
+	copy(values, e.values)
+	return values
+}
+
+// Vectors returns a matrix whose columns are the eigenvectors
+func (e *EigenSym) Vectors() *Matrix {
+	return e.vectors.Copy()
+}
+
+// SVD is the thin singular value decomposition A = U*diag(values)*V'
+// of an m x n matrix, where k = min(m, n), U is m x k, V is n x k and
+// singular values are sorted in decreasing order.
+type SVD struct {
+	u      *Matrix
+	values []float64
+	v      *Matrix
+}
+
+// NewSVD computes the thin singular value decomposition of a matrix
+// using one-sided Jacobi rotations.  The input matrix is not modified.
+func NewSVD(a *Matrix) (*SVD, error) {
+	m, n := a.Shape()
+	if m < n {
+		svd, err := NewSVD(a.T())
+		if err != nil {
+			return nil, err
+		}
+		return &SVD{u: svd.v, values: svd.values, v: svd.u}, nil
+	}
+
+	u := a.Copy()
+	v := Identity(n)
+	converged := false
+	for sweep := 0; sweep < maxSweeps && !converged; sweep++ {
+		converged = true
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				var alpha, beta, gamma float64
+				for k := 0; k < m; k++ {
+					ukp, ukq := u.data[k*n+p], u.data[k*n+q]
+					alpha += ukp * ukp
+					beta += ukq * ukq
+					gamma += ukp * ukq
+				}
+				if gamma == 0.0 || math.Abs(gamma) <= epsilon*math.Sqrt(alpha*beta) {
+					continue
+				}
+				converged = false
+				// rotate columns p and q to make them orthogonal
+				c, s := jacobiRotation(alpha, beta, gamma)
+				for k := 0; k < m; k++ {
+					ukp, ukq := u.data[k*n+p], u.data[k*n+q]
+					u.data[k*n+p] = c*ukp - s*ukq
+					u.data[k*n+q] = s*ukp + c*ukq
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := v.data[k*n+p], v.data[k*n+q]
+					v.data[k*n+p] = c*vkp - s*vkq
+					v.data[k*n+q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+	if !converged {
+		return nil, fmt.Errorf("singular value decomposition did not converge in %d sweeps", maxSweeps)
+	}
+
+	values := make([]float64, n)
+	for j := range values {
+		var nrm float64
+		for k := 0; k < m; k++ {
+			nrm = math.Hypot(nrm, u.data[k*n+j])
+		}
+		values[j] = nrm
+	}
+	order := sortedOrder(values)
+	values = permute(values, order)
+	u = permuteColumns(u, order)
+	v = permuteColumns(v, order)
+
+	tol := float64(m) * epsilon
+	if n > 0 {
+		tol *= values[0]
+	}
+	for j, sigma := range values {
+		if sigma > tol {
+			for k := 0; k < m; k++ {
+				u.data[k*n+j] /= sigma
+			}
+		} else {
+			values[j] = 0.0
+			completeBasis(u, j)
+		}
+	}
+	return &SVD{u: u, values: values, v: v}, nil
+}
+
+// completeBasis replaces column j of u with a unit vector orthogonal
+// to columns 0..j-1, which are assumed to be orthonormal
+func completeBasis(u *Matrix, j int) {
+	m, n := u.Shape()
+	col := make([]float64, m)
+	for e := 0; e < m; e++ {
+		for k := range col {
+			col[k] = 0.0
+		}
+		col[e] = 1.0
+		for c := 0; c < j; c++ {
+			var d float64
+			for k := 0; k < m; k++ {
+				d += u.data[k*n+c] * col[k]
+			}
+			for k := 0; k < m; k++ {
+				col[k] -= d * u.data[k*n+c]
+			}
+		}
+		var nrm float64
+		for _, ck := range col {
+			nrm = math.Hypot(nrm, ck)
+		}
+		if nrm > 0.5 {
+			for k := 0; k < m; k++ {
+				u.data[k*n+j] = col[k] / nrm
+			}
+			return
+		}
+	}
+}
+
+// U returns the m x k matrix of left singular vectors
+func (f *SVD) U() *Matrix {
+	return f.u.Copy()
+}
+
+// Values returns the singular values in decreasing order
+func (f *SVD) Values() []float64 {
+	values := make([]float64, len(f.values))
+	copy(values, f.values)
+	return values
+}
+
+// V returns the n x k matrix of right singular vectors
+func (f *SVD) V() *Matrix {
+	return f.v.Copy()
+}
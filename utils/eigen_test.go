@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEigenSym(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{2.0, -1.0, 0.0},
+		{-1.0, 2.0, -1.0},
+		{0.0, -1.0, 2.0},
+	})
+	eig, err := NewEigenSym(A)
+	if err != nil {
+		t.Fatalf("error calling NewEigenSym: %s", err)
+	}
+	expected := []float64{2.0 + math.Sqrt2, 2.0, 2.0 - math.Sqrt2}
+	values := eig.Values()
+	for i, v := range values {
+		if math.Abs(v-expected[i]) > 1e-12 {
+			t.Fatalf("NewEigenSym(A).Values() = %v; want %v", values, expected)
+		}
+	}
+
+	vecs := eig.Vectors()
+	for j, lambda := range values {
+		av, _ := A.MulVec(vecs.Col(j))
+		for i, avi := range av {
+			if math.Abs(avi-lambda*vecs.At(i, j)) > 1e-12 {
+				t.Fatalf("A*v[%d] = %v; want %f*v[%d]", j, av, lambda, j)
+			}
+		}
+	}
+
+	B, _ := FromRows([][]float64{{1.0, 2.0}, {3.0, 4.0}})
+	if _, err := NewEigenSym(B); err == nil {
+		t.Fatalf("NewEigenSym([[1, 2], [3, 4]]) should raise error: matrix is not symmetric")
+	}
+}
+
+func TestEigenSymSortsByMagnitude(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{1.0, 0.0},
+		{0.0, -3.0},
+	})
+	eig, _ := NewEigenSym(A)
+	if !VectorsEqual(eig.Values(), []float64{-3.0, 1.0}) {
+		t.Fatalf("NewEigenSym([[1, 0], [0, -3]]).Values() = %v; want [-3, 1]", eig.Values())
+	}
+}
+
+func reconstruct(svd *SVD) *Matrix {
+	us := svd.U()
+	m, k := us.Shape()
+	for i := 0; i < m; i++ {
+		for j := 0; j < k; j++ {
+			us.Set(i, j, us.At(i, j)*svd.Values()[j])
+		}
+	}
+	usv, _ := us.Mul(svd.V().T())
+	return usv
+}
+
+func TestSVD(t *testing.T) {
+	for _, rows := range [][][]float64{
+		{{3.0, 2.0, 2.0}, {2.0, 3.0, -2.0}},
+		{{3.0, 2.0}, {2.0, 3.0}, {2.0, -2.0}},
+	} {
+		A, _ := FromRows(rows)
+		svd, err := NewSVD(A)
+		if err != nil {
+			t.Fatalf("error calling NewSVD(%v): %s", rows, err)
+		}
+		values := svd.Values()
+		expected := []float64{5.0, 3.0}
+		for i, v := range values {
+			if math.Abs(v-expected[i]) > 1e-12 {
+				t.Fatalf("NewSVD(%v).Values() = %v; want %v", rows, values, expected)
+			}
+		}
+		usv := reconstruct(svd)
+		for i, row := range rows {
+			for j, aij := range row {
+				if math.Abs(usv.At(i, j)-aij) > 1e-12 {
+					t.Fatalf("U*S*V' = %v; want %v", usv, rows)
+				}
+			}
+		}
+	}
+}
+
+func TestSVDRankDeficient(t *testing.T) {
+	rows := [][]float64{
+		{1.0, 2.0},
+		{2.0, 4.0},
+		{3.0, 6.0},
+	}
+	A, _ := FromRows(rows)
+	svd, err := NewSVD(A)
+	if err != nil {
+		t.Fatalf("error calling NewSVD: %s", err)
+	}
+	if svd.Values()[1] != 0.0 {
+		t.Fatalf("NewSVD(%v).Values() = %v; want second value 0", rows, svd.Values())
+	}
+	utu, _ := svd.U().T().Mul(svd.U())
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if math.Abs(utu.At(i, j)-Identity(2).At(i, j)) > 1e-12 {
+				t.Fatalf("U'*U = %v; want identity", utu)
+			}
+		}
+	}
+}
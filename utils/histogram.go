@@ -0,0 +1,254 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// bounds returns the smallest and largest values of x, widened by a half
+// on each side when they are equal so there is a range to split
+func bounds[T Float](x []T) (lo, hi T) {
+	lo, hi = x[0], x[0]
+	for _, xi := range x[1:] {
+		lo, hi = min(lo, xi), max(hi, xi)
+	}
+	if lo == hi {
+		lo, hi = lo-0.5, hi+0.5
+	}
+	return lo, hi
+}
+
+// EqualWidthEdges splits the range of x into bins of equal width,
+// returning the bins+1 edges from the smallest to the largest value
+func EqualWidthEdges[T Float](x []T, bins int) ([]T, error) {
+	if err := checkLen(x, 1); err != nil {
+		return nil, err
+	}
+	if bins < 1 {
+		return nil, fmt.Errorf("need at least 1 bins, got %d", bins)
+	}
+	lo, hi := bounds(x)
+	edges := make([]T, bins+1)
+	for i := range edges {
+		edges[i] = lo + (hi-lo)*T(i)/T(bins)
+	}
+	// avoid rounding below the largest value
+	edges[bins] = hi
+	return edges, nil
+}
+
+// QuantileEdges splits x into bins holding about the same number of
+// values, with edges at its quantiles.  Edges repeated because of ties
+// are dropped, so there may be fewer bins than asked for.
+func QuantileEdges[T Float](x []T, bins int) ([]T, error) {
+	if err := checkLen(x, 1); err != nil {
+		return nil, err
+	}
+	if bins < 1 {
+		return nil, fmt.Errorf("need at least 1 bins, got %d", bins)
+	}
+	qs := make([]float64, bins+1)
+	for i := range qs {
+		qs[i] = float64(i) / float64(bins)
+	}
+	edges, _ := Quantiles(x, qs, Linear)
+	edges = slices.Compact(edges)
+	if len(edges) == 1 {
+		return []T{edges[0] - 0.5, edges[0] + 0.5}, nil
+	}
+	return edges, nil
+}
+
+// FreedmanDiaconisEdges splits the range of x into bins of equal width
+// 2 * IQR / n^(1/3), a choice that is robust to outliers.  When the
+// interquartile range is 0 there is a single bin.
+func FreedmanDiaconisEdges[T Float](x []T) ([]T, error) {
+	iqr, err := IQR(x)
+	if err != nil {
+		return nil, err
+	}
+	lo, hi := bounds(x)
+	bins := 1
+	if width := 2.0 * float64(iqr) / math.Cbrt(float64(len(x))); width > 0.0 {
+		bins = int(math.Ceil(float64(hi-lo) / width))
+	}
+	return EqualWidthEdges(x, bins)
+}
+
+// checkEdges returns an error unless there are at least
+// two edges in increasing order
+func checkEdges[T Float](edges []T) error {
+	if len(edges) < 2 {
+		return fmt.Errorf("need at least 2 edges, got %d", len(edges))
+	}
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i] > edges[i-1]) {
+			return fmt.Errorf("edges must be increasing, got %v then %v", edges[i-1], edges[i])
+		}
+	}
+	return nil
+}
+
+// binIndex returns the bin of v between sorted edges, or -1 when v is
+// outside them.  Bins include their left edge and the last bin also
+// includes its right edge.
+func binIndex[T Float](edges []T, v T) int {
+	last := len(edges) - 1
+	if !(v >= edges[0] && v <= edges[last]) {
+		return -1
+	}
+	if v == edges[last] {
+		return last - 1
+	}
+	return sort.Search(last, func(i int) bool { return edges[i+1] > v })
+}
+
+// binLabel names the bin between edges[i] and edges[i+1]
+func binLabel[T Float](edges []T, i int) string {
+	if i == len(edges)-2 {
+		return fmt.Sprintf("[%g, %g]", edges[i], edges[i+1])
+	}
+	return fmt.Sprintf("[%g, %g)", edges[i], edges[i+1])
+}
+
+// Histogram counts values falling between consecutive edges.  Bins
+// include their left edge and the last bin also includes its right edge.
+type Histogram[T Float] struct {
+	Edges  []T
+	Counts []int
+}
+
+// NewHistogram counts the values of x in the bins between edges, which
+// can come from EqualWidthEdges, QuantileEdges or FreedmanDiaconisEdges.
+// Values outside the edges are not counted.
+func NewHistogram[T Float](x []T, edges []T) (*Histogram[T], error) {
+	if err := checkEdges(edges); err != nil {
+		return nil, err
+	}
+	h := &Histogram[T]{Edges: slices.Clone(edges), Counts: make([]int, len(edges)-1)}
+	for _, xi := range x {
+		if i := binIndex(h.Edges, xi); i >= 0 {
+			h.Counts[i]++
+		}
+	}
+	return h, nil
+}
+
+// Total returns the number of values counted
+func (h *Histogram[T]) Total() int {
+	var total int
+	for _, c := range h.Counts {
+		total += c
+	}
+	return total
+}
+
+// Densities returns the count of each bin divided by the total count and
+// the bin's width, so the area of the histogram is 1 as for a density
+func (h *Histogram[T]) Densities() []float64 {
+	densities := make([]float64, len(h.Counts))
+	total := float64(h.Total())
+	if total == 0.0 {
+		return densities
+	}
+	for i, c := range h.Counts {
+		densities[i] = float64(c) / total / float64(h.Edges[i+1]-h.Edges[i])
+	}
+	return densities
+}
+
+// Bin returns the index of the bin holding v, or -1 when v is outside the edges
+func (h *Histogram[T]) Bin(v T) int {
+	return binIndex(h.Edges, v)
+}
+
+// Label names the i-th bin by its interval, such as "[1, 2.5)"
+func (h *Histogram[T]) Label(i int) string {
+	return binLabel(h.Edges, i)
+}
+
+// Discretize replaces each value of x by the label of its bin, turning
+// a continuous feature into a categorical one.  Values below or above
+// the edges are labelled "(-Inf, lo)" and "(hi, +Inf)".
+func (h *Histogram[T]) Discretize(x []T) []string {
+	last := len(h.Edges) - 1
+	labels := make([]string, len(x))
+	for j, xi := range x {
+		switch i := h.Bin(xi); {
+		case i >= 0:
+			labels[j] = h.Label(i)
+		case xi < h.Edges[0]:
+			labels[j] = fmt.Sprintf("(-Inf, %g)", h.Edges[0])
+		case xi > h.Edges[last]:
+			labels[j] = fmt.Sprintf("(%g, +Inf)", h.Edges[last])
+		default:
+			labels[j] = "NaN"
+		}
+	}
+	return labels
+}
+
+// Histogram2D counts pairs of values falling in a grid of bins,
+// Counts[i][j] counting those in x bin i and y bin j
+type Histogram2D[T Float] struct {
+	XEdges []T
+	YEdges []T
+	Counts [][]int
+}
+
+// NewHistogram2D counts the pairs (x[k], y[k]) in the grid of bins between
+// the edges.  Pairs with either value outside its edges are not counted.
+func NewHistogram2D[T Float](x, y []T, xEdges, yEdges []T) (*Histogram2D[T], error) {
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("vectors are of unequal size: %d != %d", len(x), len(y))
+	}
+	if err := checkEdges(xEdges); err != nil {
+		return nil, err
+	}
+	if err := checkEdges(yEdges); err != nil {
+		return nil, err
+	}
+	h := &Histogram2D[T]{XEdges: slices.Clone(xEdges), YEdges: slices.Clone(yEdges)}
+	h.Counts = make([][]int, len(xEdges)-1)
+	for i := range h.Counts {
+		h.Counts[i] = make([]int, len(yEdges)-1)
+	}
+	for k := range x {
+		i, j := binIndex(h.XEdges, x[k]), binIndex(h.YEdges, y[k])
+		if i >= 0 && j >= 0 {
+			h.Counts[i][j]++
+		}
+	}
+	return h, nil
+}
+
+// Total returns the number of pairs counted
+func (h *Histogram2D[T]) Total() int {
+	var total int
+	for _, row := range h.Counts {
+		for _, c := range row {
+			total += c
+		}
+	}
+	return total
+}
+
+// Densities returns the count of each bin divided by the total count and
+// the bin's area, so the volume of the histogram is 1 as for a density
+func (h *Histogram2D[T]) Densities() [][]float64 {
+	total := float64(h.Total())
+	densities := make([][]float64, len(h.Counts))
+	for i, row := range h.Counts {
+		densities[i] = make([]float64, len(row))
+		if total == 0.0 {
+			continue
+		}
+		for j, c := range row {
+			area := float64(h.XEdges[i+1]-h.XEdges[i]) * float64(h.YEdges[j+1]-h.YEdges[j])
+			densities[i][j] = float64(c) / total / area
+		}
+	}
+	return densities
+}
@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+var binData = []float64{1.0, 2.0, 2.0, 3.0, 3.0, 3.0, 4.0, 4.0, 5.0, 10.0}
+
+func TestEqualWidthEdges(t *testing.T) {
+	edges, err := EqualWidthEdges(binData, 3)
+	if err != nil {
+		t.Fatalf("error calling EqualWidthEdges(binData, 3): %s", err)
+	}
+	if !slices.Equal(edges, []float64{1.0, 4.0, 7.0, 10.0}) {
+		t.Fatalf("EqualWidthEdges(binData, 3) = %v; want [1 4 7 10]", edges)
+	}
+	h, _ := NewHistogram(binData, edges)
+	if !slices.Equal(h.Counts, []int{6, 3, 1}) {
+		t.Fatalf("NewHistogram(binData, %v).Counts = %v; want [6 3 1]", edges, h.Counts)
+	}
+	if edges, _ := EqualWidthEdges([]float64{2.0, 2.0}, 2); !slices.Equal(edges, []float64{1.5, 2.0, 2.5}) {
+		t.Fatalf("EqualWidthEdges([2 2], 2) = %v; want [1.5 2 2.5]", edges)
+	}
+	if _, err := EqualWidthEdges(binData, 0); err == nil {
+		t.Fatalf("EqualWidthEdges(binData, 0) should raise error: need at least 1 bins, got 0")
+	}
+}
+
+func TestQuantileEdges(t *testing.T) {
+	edges, err := QuantileEdges(binData, 4)
+	if err != nil {
+		t.Fatalf("error calling QuantileEdges(binData, 4): %s", err)
+	}
+	if !slices.Equal(edges, []float64{1.0, 2.25, 3.0, 4.0, 10.0}) {
+		t.Fatalf("QuantileEdges(binData, 4) = %v; want [1 2.25 3 4 10]", edges)
+	}
+	h, _ := NewHistogram(binData, edges)
+	if !slices.Equal(h.Counts, []int{3, 0, 3, 4}) {
+		t.Fatalf("NewHistogram(binData, %v).Counts = %v; want [3 0 3 4]", edges, h.Counts)
+	}
+	// ties repeat the edges at 3, which are dropped
+	if edges, _ := QuantileEdges([]float64{1, 3, 3, 3, 3, 3, 5}, 3); !slices.Equal(edges, []float64{1.0, 3.0, 5.0}) {
+		t.Fatalf("QuantileEdges([1 3 3 3 3 3 5], 3) = %v; want [1 3 5]", edges)
+	}
+}
+
+func TestFreedmanDiaconisEdges(t *testing.T) {
+	// IQR 1.75 gives width 1.62, so 6 bins over [1, 10]
+	edges, err := FreedmanDiaconisEdges(binData)
+	if err != nil {
+		t.Fatalf("error calling FreedmanDiaconisEdges(binData): %s", err)
+	}
+	if !slices.Equal(edges, []float64{1.0, 2.5, 4.0, 5.5, 7.0, 8.5, 10.0}) {
+		t.Fatalf("FreedmanDiaconisEdges(binData) = %v; want [1 2.5 4 5.5 7 8.5 10]", edges)
+	}
+	h, _ := NewHistogram(binData, edges)
+	if !slices.Equal(h.Counts, []int{3, 3, 3, 0, 0, 1}) || h.Total() != 10 {
+		t.Fatalf("NewHistogram(binData, %v).Counts = %v; want [3 3 3 0 0 1]", edges, h.Counts)
+	}
+	var area float64
+	for i, d := range h.Densities() {
+		area += d * (edges[i+1] - edges[i])
+	}
+	if d := h.Densities()[0]; math.Abs(d-0.2) > 1e-12 || math.Abs(area-1.0) > 1e-12 {
+		t.Fatalf("Densities()[0] = %f with area %f; want 0.2 and 1", d, area)
+	}
+	if edges, _ := FreedmanDiaconisEdges([]float64{1, 2, 2, 2, 2, 2, 9}); len(edges) != 2 {
+		t.Fatalf("FreedmanDiaconisEdges with IQR 0 = %v; want a single bin", edges)
+	}
+	if _, err := FreedmanDiaconisEdges([]float64{}); err == nil {
+		t.Fatalf("FreedmanDiaconisEdges([]) should raise error: need at least 1 values, got 0")
+	}
+}
+
+func TestHistogramDiscretize(t *testing.T) {
+	h, err := NewHistogram(binData, []float64{1.0, 4.0, 7.0, 10.0})
+	if err != nil {
+		t.Fatalf("error calling NewHistogram(binData, [1 4 7 10]): %s", err)
+	}
+	if h.Bin(4.0) != 1 || h.Bin(10.0) != 2 || h.Bin(0.5) != -1 || h.Bin(math.NaN()) != -1 {
+		t.Fatalf("Bin(4), Bin(10), Bin(0.5), Bin(NaN) = %d, %d, %d, %d; want 1, 2, -1, -1",
+			h.Bin(4.0), h.Bin(10.0), h.Bin(0.5), h.Bin(math.NaN()))
+	}
+	labels := h.Discretize([]float64{1.0, 6.5, 10.0, 0.0, 12.0})
+	want := []string{"[1, 4)", "[4, 7)", "[7, 10]", "(-Inf, 1)", "(10, +Inf)"}
+	if !slices.Equal(labels, want) {
+		t.Fatalf("Discretize([1 6.5 10 0 12]) = %q; want %q", labels, want)
+	}
+	if _, err := NewHistogram(binData, []float64{1.0, 1.0, 2.0}); err == nil {
+		t.Fatalf("NewHistogram with repeated edges should raise error: edges must be increasing")
+	}
+	if _, err := NewHistogram(binData, []float64{1.0}); err == nil {
+		t.Fatalf("NewHistogram with 1 edge should raise error: need at least 2 edges, got 1")
+	}
+}
+
+func TestHistogram2D(t *testing.T) {
+	x := []float64{1.0, 2.0, 3.0, 4.0, 5.0}
+	y := []float64{1.0, 1.0, 2.0, 2.0, 2.0}
+	h, err := NewHistogram2D(x, y, []float64{0.0, 2.0, 4.0}, []float64{0.0, 1.5, 3.0})
+	if err != nil {
+		t.Fatalf("error calling NewHistogram2D(x, y): %s", err)
+	}
+	// x = 5 is outside the edges
+	if !slices.Equal(h.Counts[0], []int{1, 0}) || !slices.Equal(h.Counts[1], []int{1, 2}) || h.Total() != 4 {
+		t.Fatalf("NewHistogram2D(x, y).Counts = %v; want [[1 0] [1 2]]", h.Counts)
+	}
+	if d := h.Densities(); math.Abs(d[1][1]-2.0/12.0) > 1e-12 {
+		t.Fatalf("Histogram2D.Densities()[1][1] = %f; want %f", d[1][1], 2.0/12.0)
+	}
+	if _, err := NewHistogram2D(x, y[1:], []float64{0.0, 2.0}, []float64{0.0, 3.0}); err == nil {
+		t.Fatalf("NewHistogram2D with short y should raise error: vectors are of unequal size")
+	}
+}
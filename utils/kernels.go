@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"runtime"
+	"sync"
+)
+
+// blockSize is the edge length of the tiles the matrix multiplication
+// kernel works through, chosen so a tile of each operand fits in L1 cache
+const blockSize = 64
+
+// parallelThreshold is the number of multiply-adds below which
+// the kernels run on a single goroutine
+const parallelThreshold = 1 << 16
+
+// DefaultWorkers returns the number of goroutines used by MatMult and Dot
+func DefaultWorkers() int {
+	return runtime.GOMAXPROCS(0)
+}
+
+// dotKernel returns the dot product of two equal length vectors,
+// accumulating four partial sums at a time
+func dotKernel[T Float](x, y []T) T {
+	var s0, s1, s2, s3 T
+	y = y[:len(x)]
+	for len(x) >= 4 {
+		s0 += x[0] * y[0]
+		s1 += x[1] * y[1]
+		s2 += x[2] * y[2]
+		s3 += x[3] * y[3]
+		x, y = x[4:], y[4:]
+	}
+	for i, xi := range x {
+		s0 += xi * y[i]
+	}
+	return (s0 + s1) + (s2 + s3)
+}
+
+// axpyKernel computes y += a*x for equal length vectors
+func axpyKernel[T Float](a T, x, y []T) {
+	y = y[:len(x)]
+	for len(x) >= 4 {
+		y[0] += a * x[0]
+		y[1] += a * x[1]
+		y[2] += a * x[2]
+		y[3] += a * x[3]
+		x, y = x[4:], y[4:]
+	}
+	for i, xi := range x {
+		y[i] += a * xi
+	}
+}
+
+// dotParallel splits a dot product into contiguous chunks, one per worker.
+// Partial sums are combined in chunk order so the result does not
+// depend on goroutine scheduling.
+func dotParallel[T Float](x, y []T, workers int) T {
+	if workers < 1 {
+		workers = 1
+	}
+	if len(x) < parallelThreshold || workers == 1 {
+		return dotKernel(x, y)
+	}
+	chunk := (len(x) + workers - 1) / workers
+	partials := make([]T, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start, end := w*chunk, (w+1)*chunk
+		if end > len(x) {
+			end = len(x)
+		}
+		if start >= end {
+			break
+		}
+		wg.Add(1)
+		go func(w, start, end int) {
+			defer wg.Done()
+			partials[w] = dotKernel(x[start:end], y[start:end])
+		}(w, start, end)
+	}
+	wg.Wait()
+	var dot T
+	for _, p := range partials {
+		dot += p
+	}
+	return dot
+}
+
+// matMultKernel computes c += a*b one tile at a time, where a is n x m,
+// b is m x p and c is n x p.  Blocks of rows of c are shared out between
+// workers, and each element of c accumulates its products in increasing
+// order of k, exactly as the naive triple loop does.
+func matMultKernel[T Float](c, a, b [][]T, workers int) {
+	n := len(a)
+	if n == 0 || len(b) == 0 {
+		return
+	}
+	m, p := len(b), len(b[0])
+	if workers < 1 {
+		workers = 1
+	}
+	if n*m*p < parallelThreshold {
+		workers = 1
+	}
+
+	rowBlocks := make(chan int, (n+blockSize-1)/blockSize)
+	for ii := 0; ii < n; ii += blockSize {
+		rowBlocks <- ii
+	}
+	close(rowBlocks)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ii := range rowBlocks {
+				iEnd := min(ii+blockSize, n)
+				for kk := 0; kk < m; kk += blockSize {
+					kEnd := min(kk+blockSize, m)
+					for jj := 0; jj < p; jj += blockSize {
+						jEnd := min(jj+blockSize, p)
+						for i := ii; i < iEnd; i++ {
+							ci, ai := c[i][jj:jEnd], a[i]
+							for k := kk; k < kEnd; k++ {
+								axpyKernel(ai[k], b[k][jj:jEnd], ci)
+							}
+						}
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -5,7 +5,7 @@ import (
 )
 
 // VectorsEqual determine if two vectors are equal in their elements
-func VectorsEqual(x, y []float64) bool {
+func VectorsEqual[T Float](x, y []T) bool {
 	if len(x) != len(y) {
 		return false
 	}
@@ -18,24 +18,29 @@ func VectorsEqual(x, y []float64) bool {
 }
 
 // Dot returns the dot or inner product between vectors
-func Dot(x, y []float64) (float64, error) {
+func Dot[T Float](x, y []T) (T, error) {
 	if len(x) != len(y) {
 		return 0.0, fmt.Errorf("vectors are of unequal size: %d != %d", len(x), len(y))
 	}
-	var dot float64
-	for i, xi := range x {
-		dot += xi * y[i]
+	return dotKernel(x, y), nil
+}
+
+// DotParallel returns the dot product of two vectors,
+// splitting long vectors between the given number of goroutines
+func DotParallel[T Float](x, y []T, workers int) (T, error) {
+	if len(x) != len(y) {
+		return 0.0, fmt.Errorf("vectors are of unequal size: %d != %d", len(x), len(y))
 	}
-	return dot, nil
+	return dotParallel(x, y, workers), nil
 }
 
 //SumOfSquares sums the squared elements of a vector
-func SumOfSquares(x []float64) (float64, error) {
+func SumOfSquares[T Float](x []T) (T, error) {
 	return Dot(x, x)
 }
 
 // VectorSum sums the elements in a vector
-func VectorSum(x []float64) (vsum float64) {
+func VectorSum[T Float](x []T) (vsum T) {
 	for _, xi := range x {
 		vsum += xi
 	}
@@ -43,11 +48,11 @@ func VectorSum(x []float64) (vsum float64) {
 }
 
 // VectorAdd adds two vectors elementwise
-func VectorAdd(x, y []float64) ([]float64, error) {
+func VectorAdd[T Float](x, y []T) ([]T, error) {
 	if len(x) != len(y) {
-		return []float64{}, fmt.Errorf("vectors are of unequal size: %d != %d", len(x), len(y))
+		return []T{}, fmt.Errorf("vectors are of unequal size: %d != %d", len(x), len(y))
 	}
-	vsum := make([]float64, len(x))
+	vsum := make([]T, len(x))
 	for i, xi := range x {
 		vsum[i] = xi + y[i]
 	}
@@ -55,11 +60,11 @@ func VectorAdd(x, y []float64) ([]float64, error) {
 }
 
 // VectorSub subtracts two vectors elementwise
-func VectorSub(x, y []float64) ([]float64, error) {
+func VectorSub[T Float](x, y []T) ([]T, error) {
 	if len(x) != len(y) {
-		return []float64{}, fmt.Errorf("vectors are of unequal size: %d != %d", len(x), len(y))
+		return []T{}, fmt.Errorf("vectors are of unequal size: %d != %d", len(x), len(y))
 	}
-	vsub := make([]float64, len(x))
+	vsub := make([]T, len(x))
 	for i, xi := range x {
 		vsub[i] = xi - y[i]
 	}
@@ -67,8 +72,8 @@ func VectorSub(x, y []float64) ([]float64, error) {
 }
 
 // ScalarMultiply multiplies each element of a vector by a scalar
-func ScalarMultiply(s float64, vec []float64) []float64 {
-	svec := make([]float64, len(vec))
+func ScalarMultiply[T Float](s T, vec []T) []T {
+	svec := make([]T, len(vec))
 	for i, v := range vec {
 		svec[i] = s * v
 	}
@@ -76,7 +81,7 @@ func ScalarMultiply(s float64, vec []float64) []float64 {
 }
 
 // SquaredDistance computes the sum of squared deviations between two vectors
-func SquaredDistance(x, y []float64) (float64, error) {
+func SquaredDistance[T Float](x, y []T) (T, error) {
 	diff, err := VectorSub(x, y)
 	if err != nil {
 		return 0.0, err
@@ -89,16 +94,19 @@ func SquaredDistance(x, y []float64) (float64, error) {
 }
 
 // Shape returns the dimensions of a nested slice (matrix)
-func Shape(mat [][]float64) (nrow, ncol int) {
+func Shape[T Float](mat [][]T) (nrow, ncol int) {
+	if len(mat) == 0 {
+		return 0, 0
+	}
 	return len(mat), len(mat[0])
 }
 
 // Transpose flips a matrix along its axes
-func Transpose(mat [][]float64) [][]float64 {
+func Transpose[T Float](mat [][]T) [][]T {
 	nrow, ncol := Shape(mat)
-	tmat := make([][]float64, ncol)
+	tmat := make([][]T, ncol)
 	for i := 0; i < ncol; i++ {
-		tmat[i] = make([]float64, nrow)
+		tmat[i] = make([]T, nrow)
 		for j := 0; j < nrow; j++ {
 			tmat[i][j] = mat[j][i]
 		}
@@ -107,8 +115,8 @@ func Transpose(mat [][]float64) [][]float64 {
 }
 
 // GetColumn returns a column of a nested slice (matrix) as a slice
-func GetColumn(mat [][]float64, c int) []float64 {
-	column := make([]float64, len(mat))
+func GetColumn[T Float](mat [][]T, c int) []T {
+	column := make([]T, len(mat))
 	for i, row := range mat {
 		column[i] = row[c]
 	}
@@ -116,12 +124,13 @@ func GetColumn(mat [][]float64, c int) []float64 {
 }
 
 // ColumnSums sums elements of a nested slice (matrix) along the first axis
-func ColumnSums(mat [][]float64) ([]float64, error) {
-	colsums := make([]float64, len(mat[0]))
+func ColumnSums[T Float](mat [][]T) ([]T, error) {
+	_, ncol := Shape(mat)
+	colsums := make([]T, ncol)
 	for _, row := range mat {
 		for j, col := range row {
-			if j > len(colsums) {
-				return []float64{}, fmt.Errorf("ValueError: bad vector %v", row)
+			if j >= len(colsums) {
+				return []T{}, fmt.Errorf("ValueError: bad vector %v", row)
 			}
 			colsums[j] += col
 		}
@@ -130,18 +139,18 @@ func ColumnSums(mat [][]float64) ([]float64, error) {
 }
 
 // ColumnMeans averages elements of a nested slice (matrix) along the first axis
-func ColumnMeans(mat [][]float64) ([]float64, error) {
+func ColumnMeans[T Float](mat [][]T) ([]T, error) {
 	colsums, err := ColumnSums(mat)
 	if err != nil {
-		return []float64{}, err
+		return []T{}, err
 	}
-	n := float64(len(mat))
+	n := T(len(mat))
 	return ScalarMultiply(1.0/n, colsums), nil
 }
 
 // RowSums sums elements of a nested slice (matrix) along the first axis
-func RowSums(mat [][]float64) []float64 {
-	rowsums := make([]float64, len(mat[0]))
+func RowSums[T Float](mat [][]T) []T {
+	rowsums := make([]T, len(mat))
 	for i, row := range mat {
 		rowsums[i] = VectorSum(row)
 	}
@@ -149,36 +158,44 @@ func RowSums(mat [][]float64) []float64 {
 }
 
 // RowMeans averages elements of a nested slice (matrix) along the first axis
-func RowMeans(mat [][]float64) []float64 {
-	rowmeans := make([]float64, len(mat))
+func RowMeans[T Float](mat [][]T) []T {
+	rowmeans := make([]T, len(mat))
 	for i, row := range mat {
 		rowsum := VectorSum(row)
-		n := float64(len(row))
+		n := T(len(row))
 		rowmeans[i] = rowsum / n
 	}
 	return rowmeans
 }
 
 // ColumnStds returns the standard deviation of each column
-func ColumnStds(mat [][]float64) []float64 {
-	colStds := make([]float64, len(mat[0]))
-	for i := range mat[0] {
-		colStds[i] = StandardDeviation(GetColumn(mat, i))
+func ColumnStds[T Float](mat [][]T) ([]T, error) {
+	_, ncol := Shape(mat)
+	colStds := make([]T, ncol)
+	for i := range colStds {
+		std, err := StandardDeviation(GetColumn(mat, i))
+		if err != nil {
+			return []T{}, err
+		}
+		colStds[i] = std
 	}
-	return colStds
+	return colStds, nil
 }
 
 // Normalize scales a matrix to have columns with 0 mean and 1 std
-func Normalize(mat [][]float64) ([][]float64, error) {
+func Normalize[T Float](mat [][]T) ([][]T, error) {
 	colMeans, err := ColumnMeans(mat)
 	if err != nil {
 		return mat, err
 	}
-	colStds := ColumnStds(mat)
+	colStds, err := ColumnStds(mat)
+	if err != nil {
+		return mat, err
+	}
 
-	normalized := make([][]float64, len(mat))
+	normalized := make([][]T, len(mat))
 	for i, row := range mat {
-		normalized[i] = make([]float64, len(row))
+		normalized[i] = make([]T, len(row))
 		for j := range row {
 			if colStds[j] > 0.0 {
 				normalized[i][j] = (row[j] - colMeans[j]) / colStds[j]
@@ -190,8 +207,14 @@ func Normalize(mat [][]float64) ([][]float64, error) {
 	return normalized, nil
 }
 
-// MatMult performs matrix multiplication
-func MatMult(a, b [][]float64) ([][]float64, error) {
+// MatMult performs matrix multiplication using all available processors
+func MatMult[T Float](a, b [][]T) ([][]T, error) {
+	return MatMultParallel(a, b, DefaultWorkers())
+}
+
+// MatMultParallel performs cache-blocked matrix multiplication,
+// sharing the rows of the result between the given number of goroutines
+func MatMultParallel[T Float](a, b [][]T, workers int) ([][]T, error) {
 	nrowa, ncola := Shape(a)
 	nrowb, ncolb := Shape(b)
 	if ncola != nrowb {
@@ -199,14 +222,10 @@ func MatMult(a, b [][]float64) ([][]float64, error) {
 			"incompatible shapes: (%d,%d), (%d,%d)",
 			nrowa, ncola, nrowb, ncolb)
 	}
-	returnMat := make([][]float64, nrowa)
-	for i := 0; i < nrowa; i++ {
-		returnMat[i] = make([]float64, ncolb)
-		for j := 0; j < ncolb; j++ {
-			for k := 0; k < ncola; k++ {
-				returnMat[i][j] += a[i][k] * b[k][j]
-			}
-		}
+	returnMat := make([][]T, nrowa)
+	for i := range returnMat {
+		returnMat[i] = make([]T, ncolb)
 	}
+	matMultKernel(returnMat, a, b, workers)
 	return returnMat, nil
 }
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"fmt"
 	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -206,3 +208,159 @@ func TestMatMult(t *testing.T) {
 		)
 	}
 }
+
+func TestFloat32(t *testing.T) {
+	x := []float32{1.0, 2.0, 3.0, 4.0, 5.0}
+	y := []float32{2.0, 3.0, 4.0, 5.0, 6.0}
+	dot, err := Dot(x, y)
+	if err != nil {
+		t.Fatalf("error calling Dot[float32]: %s", err)
+	}
+	if dot != 70.0 {
+		t.Fatalf("Dot[float32]([1, 2, 3, 4, 5], [2, 3, 4, 5, 6]) = %f; want 70.0", dot)
+	}
+	sum, _ := VectorAdd(x, y)
+	if !VectorsEqual(sum, []float32{3.0, 5.0, 7.0, 9.0, 11.0}) {
+		t.Fatalf("VectorAdd[float32]([1, 2, 3, 4, 5], [2, 3, 4, 5, 6]) = %v; want [3, 5, 7, 9, 11]", sum)
+	}
+
+	A := [][]float32{
+		{1.0, 2.0, 3.0, 4.0, 5.0},
+		{2.0, 3.0, 4.0, 5.0, 6.0},
+	}
+	actual, err := MatMult(A, Transpose(A))
+	if err != nil {
+		t.Fatalf("error calling MatMult[float32]: %s", err)
+	}
+	expected := [][]float32{
+		{55.0, 70.0},
+		{70.0, 90.0},
+	}
+	for i, row := range actual {
+		if !VectorsEqual(row, expected[i]) {
+			t.Fatalf("MatMult[float32](A, A')[%d] = %v; want %v", i, row, expected[i])
+		}
+	}
+}
+
+// matMultNaive is the original triple loop, kept as a reference
+// for the blocked kernel
+func matMultNaive(a, b [][]float64) [][]float64 {
+	nrowa, ncola := Shape(a)
+	_, ncolb := Shape(b)
+	returnMat := make([][]float64, nrowa)
+	for i := 0; i < nrowa; i++ {
+		returnMat[i] = make([]float64, ncolb)
+		for j := 0; j < ncolb; j++ {
+			for k := 0; k < ncola; k++ {
+				returnMat[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return returnMat
+}
+
+func randomMatrix(r *rand.Rand, nrow, ncol int) [][]float64 {
+	mat := make([][]float64, nrow)
+	for i := range mat {
+		mat[i] = make([]float64, ncol)
+		for j := range mat[i] {
+			mat[i][j] = r.NormFloat64()
+		}
+	}
+	return mat
+}
+
+func TestMatMultParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	// sizes that are not multiples of the block size
+	A := randomMatrix(r, 131, 77)
+	B := randomMatrix(r, 77, 150)
+	expected := matMultNaive(A, B)
+	for _, workers := range []int{1, 3, 8} {
+		actual, err := MatMultParallel(A, B, workers)
+		if err != nil {
+			t.Fatalf("error calling MatMultParallel with %d workers: %s", workers, err)
+		}
+		for i, row := range actual {
+			for j, v := range row {
+				if math.Abs(v-expected[i][j]) > 1e-12 {
+					t.Fatalf(
+						"MatMultParallel(A, B, %d)[%d][%d] = %v; want %v",
+						workers, i, j, v, expected[i][j])
+				}
+			}
+		}
+	}
+}
+
+func TestDotParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	x := randomMatrix(r, 1, 200000)[0]
+	y := randomMatrix(r, 1, 200000)[0]
+	var expected float64
+	for i, xi := range x {
+		expected += xi * y[i]
+	}
+	actual, err := DotParallel(x, y, 4)
+	if err != nil {
+		t.Fatalf("error calling DotParallel: %s", err)
+	}
+	if math.Abs(actual-expected) > 1e-9 {
+		t.Fatalf("DotParallel(x, y, 4) = %v; want %v", actual, expected)
+	}
+	if _, err := DotParallel(x, y[1:], 4); err == nil {
+		t.Fatalf("DotParallel with lengths 200000, 199999 should raise error: unequal size")
+	}
+}
+
+func BenchmarkMatMult(b *testing.B) {
+	r := rand.New(rand.NewSource(42))
+	for _, n := range []int{128, 512} {
+		A := randomMatrix(r, n, n)
+		B := randomMatrix(r, n, n)
+		b.Run(fmt.Sprintf("naive/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				matMultNaive(A, B)
+			}
+		})
+		b.Run(fmt.Sprintf("blocked-1/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				MatMultParallel(A, B, 1)
+			}
+		})
+		b.Run(fmt.Sprintf("blocked-default/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				MatMult(A, B)
+			}
+		})
+	}
+}
+
+// benchSink keeps benchmarked results alive so they are not optimized away
+var benchSink float64
+
+func BenchmarkDot(b *testing.B) {
+	r := rand.New(rand.NewSource(42))
+	x := randomMatrix(r, 1, 1<<20)[0]
+	y := randomMatrix(r, 1, 1<<20)[0]
+	b.Run("naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var dot float64
+			for j, xj := range x {
+				dot += xj * y[j]
+			}
+			benchSink = dot
+		}
+	})
+	b.Run("unrolled", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchSink, _ = Dot(x, y)
+		}
+	})
+	b.Run("parallel-default", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchSink, _ = DotParallel(x, y, DefaultWorkers())
+		}
+	})
+}
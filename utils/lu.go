@@ -0,0 +1,213 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+)
+
+// LU is the LU factorization of a square matrix with partial pivoting,
+// such that P*A = L*U where P is a row permutation, L is unit lower
+// triangular and U is upper triangular.
+type LU struct {
+	lu    *Matrix // L strictly below the diagonal, U on and above it
+	pivot []int   // pivot[i] is the row of A that was moved to row i
+	sign  float64 // determinant of P
+	tol   float64 // pivots at or below tol in magnitude are treated as zero
+}
+
+// NewLU factorizes a square matrix using Gaussian elimination
+// with partial (row) pivoting.  The input matrix is not modified.
+func NewLU(a *Matrix) (*LU, error) {
+	n, ncol := a.Shape()
+	if n != ncol {
+		return nil, fmt.Errorf("matrix is not square: (%d,%d)", n, ncol)
+	}
+	lu := a.Copy()
+	pivot := make([]int, n)
+	for i := range pivot {
+		pivot[i] = i
+	}
+	sign := 1.0
+
+	var maxAbs float64
+	for _, v := range lu.data {
+		maxAbs = math.Max(maxAbs, math.Abs(v))
+	}
+
+	for k := 0; k < n; k++ {
+		// find the largest remaining element in column k
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu.data[i*n+k]) > math.Abs(lu.data[p*n+k]) {
+				p = i
+			}
+		}
+		if p != k {
+			rowp, rowk := lu.rowView(p), lu.rowView(k)
+			for j := range rowk {
+				rowp[j], rowk[j] = rowk[j], rowp[j]
+			}
+			pivot[p], pivot[k] = pivot[k], pivot[p]
+			sign = -sign
+		}
+
+		ukk := lu.data[k*n+k]
+		if ukk == 0.0 {
+			continue
+		}
+		rowk := lu.rowView(k)
+		for i := k + 1; i < n; i++ {
+			rowi := lu.rowView(i)
+			rowi[k] /= ukk
+			lik := rowi[k]
+			for j := k + 1; j < n; j++ {
+				rowi[j] -= lik * rowk[j]
+			}
+		}
+	}
+
+	return &LU{
+		lu:    lu,
+		pivot: pivot,
+		sign:  sign,
+		tol:   float64(n) * maxAbs * epsilon,
+	}, nil
+}
+
+// epsilon is the spacing between 1.0 and the next float64
+const epsilon = 2.220446049250313e-16
+
+// Singular reports whether the factorized matrix is numerically singular
+func (f *LU) Singular() bool {
+	n, _ := f.lu.Shape()
+	for i := 0; i < n; i++ {
+		if math.Abs(f.lu.data[i*n+i]) <= f.tol {
+			return true
+		}
+	}
+	return false
+}
+
+// L returns the unit lower triangular factor
+func (f *LU) L() *Matrix {
+	n, _ := f.lu.Shape()
+	l := Identity(n)
+	for i := 1; i < n; i++ {
+		copy(l.data[i*n:i*n+i], f.lu.data[i*n:i*n+i])
+	}
+	return l
+}
+
+// U returns the upper triangular factor
+func (f *LU) U() *Matrix {
+	n, _ := f.lu.Shape()
+	u := Zeros(n, n)
+	for i := 0; i < n; i++ {
+		copy(u.data[i*n+i:(i+1)*n], f.lu.data[i*n+i:(i+1)*n])
+	}
+	return u
+}
+
+// Pivot returns the row permutation, where row i of P*A is row Pivot()[i] of A
+func (f *LU) Pivot() []int {
+	pivot := make([]int, len(f.pivot))
+	copy(pivot, f.pivot)
+	return pivot
+}
+
+// Det returns the determinant of the factorized matrix
+func (f *LU) Det() float64 {
+	n, _ := f.lu.Shape()
+	det := f.sign
+	for i := 0; i < n; i++ {
+		det *= f.lu.data[i*n+i]
+	}
+	return det
+}
+
+// Solve finds x such that A*x = b
+func (f *LU) Solve(b []float64) ([]float64, error) {
+	n, _ := f.lu.Shape()
+	if len(b) != n {
+		return nil, fmt.Errorf(
+			"incompatible shapes: (%d,%d), (%d,)",
+			n, n, len(b))
+	}
+	if f.Singular() {
+		return nil, fmt.Errorf("matrix is singular")
+	}
+
+	// forward substitution with L on the permuted b
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = b[f.pivot[i]]
+		row := f.lu.rowView(i)
+		for j := 0; j < i; j++ {
+			x[i] -= row[j] * x[j]
+		}
+	}
+	// back substitution with U
+	for i := n - 1; i >= 0; i-- {
+		row := f.lu.rowView(i)
+		for j := i + 1; j < n; j++ {
+			x[i] -= row[j] * x[j]
+		}
+		x[i] /= row[i]
+	}
+	return x, nil
+}
+
+// SolveMatrix finds X such that A*X = B, solving for each column of B
+func (f *LU) SolveMatrix(b *Matrix) (*Matrix, error) {
+	n, _ := f.lu.Shape()
+	nrowb, ncolb := b.Shape()
+	if nrowb != n {
+		return nil, fmt.Errorf(
+			"incompatible shapes: (%d,%d), (%d,%d)",
+			n, n, nrowb, ncolb)
+	}
+	x := Zeros(n, ncolb)
+	for j := 0; j < ncolb; j++ {
+		col, err := f.Solve(b.Col(j))
+		if err != nil {
+			return nil, err
+		}
+		for i, v := range col {
+			x.data[i*x.stride+j] = v
+		}
+	}
+	return x, nil
+}
+
+// Inverse returns the inverse of the factorized matrix
+func (f *LU) Inverse() (*Matrix, error) {
+	n, _ := f.lu.Shape()
+	return f.SolveMatrix(Identity(n))
+}
+
+// Solve finds x such that a*x = b for a square matrix a
+func Solve(a *Matrix, b []float64) ([]float64, error) {
+	lu, err := NewLU(a)
+	if err != nil {
+		return nil, err
+	}
+	return lu.Solve(b)
+}
+
+// Inverse returns the inverse of a square matrix
+func Inverse(a *Matrix) (*Matrix, error) {
+	lu, err := NewLU(a)
+	if err != nil {
+		return nil, err
+	}
+	return lu.Inverse()
+}
+
+// Determinant returns the determinant of a square matrix
+func Determinant(a *Matrix) (float64, error) {
+	lu, err := NewLU(a)
+	if err != nil {
+		return 0.0, err
+	}
+	return lu.Det(), nil
+}
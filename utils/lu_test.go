@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLUFactors(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{2.0, 1.0, 1.0},
+		{4.0, -6.0, 0.0},
+		{-2.0, 7.0, 2.0},
+	})
+	lu, err := NewLU(A)
+	if err != nil {
+		t.Fatalf("error calling NewLU: %s", err)
+	}
+	prod, _ := lu.L().Mul(lu.U())
+	for i, p := range lu.Pivot() {
+		for j := 0; j < 3; j++ {
+			if math.Abs(prod.At(i, j)-A.At(p, j)) > 1e-12 {
+				t.Fatalf("(L*U)[%d][%d] = %f; want %f", i, j, prod.At(i, j), A.At(p, j))
+			}
+		}
+	}
+}
+
+func TestLUSolve(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{2.0, 1.0, 1.0},
+		{4.0, -6.0, 0.0},
+		{-2.0, 7.0, 2.0},
+	})
+	actual, err := Solve(A, []float64{5.0, -2.0, 9.0})
+	if err != nil {
+		t.Fatalf("error calling Solve: %s", err)
+	}
+	expected := []float64{1.0, 1.0, 2.0}
+	for i, xi := range actual {
+		if math.Round(xi*1e9)/1e9 != expected[i] {
+			t.Fatalf("Solve(A, [5, -2, 9]) = %v; want %v", actual, expected)
+		}
+	}
+
+	if _, err := Solve(A, []float64{1.0, 2.0}); err == nil {
+		t.Fatalf("Solve(A, [1, 2]) should raise error: incompatible shapes")
+	}
+}
+
+func TestDeterminant(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{2.0, 1.0, 1.0},
+		{4.0, -6.0, 0.0},
+		{-2.0, 7.0, 2.0},
+	})
+	actual, err := Determinant(A)
+	if err != nil {
+		t.Fatalf("error calling Determinant: %s", err)
+	}
+	if math.Round(actual*1e9)/1e9 != -16.0 {
+		t.Fatalf("Determinant(A) = %f; want -16", actual)
+	}
+
+	if _, err := Determinant(Zeros(2, 3)); err == nil {
+		t.Fatalf("Determinant of a (2,3) matrix should raise error: matrix is not square")
+	}
+}
+
+func TestInverse(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{4.0, 7.0},
+		{2.0, 6.0},
+	})
+	inv, err := Inverse(A)
+	if err != nil {
+		t.Fatalf("error calling Inverse: %s", err)
+	}
+	I, _ := A.Mul(inv)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if math.Abs(I.At(i, j)-Identity(2).At(i, j)) > 1e-12 {
+				t.Fatalf("A * Inverse(A) = %v; want identity", I)
+			}
+		}
+	}
+}
+
+func TestSingular(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{1.0, 2.0, 3.0},
+		{2.0, 4.0, 6.0},
+		{1.0, 0.0, 1.0},
+	})
+	lu, _ := NewLU(A)
+	if !lu.Singular() {
+		t.Fatalf("NewLU([[1, 2, 3], [2, 4, 6], [1, 0, 1]]).Singular() = false; want true")
+	}
+	if _, err := lu.Inverse(); err == nil {
+		t.Fatalf("Inverse of a singular matrix should raise error: matrix is singular")
+	}
+	if det := lu.Det(); math.Abs(det) > 1e-12 {
+		t.Fatalf("Det of a singular matrix = %f; want 0", det)
+	}
+}
@@ -0,0 +1,290 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Matrix is a dense matrix of float64 values stored contiguously
+// in row-major order.
+//
+// A Matrix returned by Slice is a view that shares storage with the
+// matrix it was taken from; stride is the distance between the start
+// of consecutive rows in the shared storage.
+type Matrix struct {
+	rows, cols int
+	stride     int
+	data       []float64
+}
+
+// NewMatrix creates a rows x cols matrix backed by data in row-major order.
+// If data is nil a zero matrix is allocated, otherwise its length
+// must equal rows*cols.  The matrix takes ownership of data.
+func NewMatrix(rows, cols int, data []float64) (*Matrix, error) {
+	if rows < 0 || cols < 0 {
+		return nil, fmt.Errorf("invalid dimensions: (%d,%d)", rows, cols)
+	}
+	if data == nil {
+		data = make([]float64, rows*cols)
+	}
+	if len(data) != rows*cols {
+		return nil, fmt.Errorf(
+			"data length %d does not match shape (%d,%d)",
+			len(data), rows, cols)
+	}
+	return &Matrix{rows: rows, cols: cols, stride: cols, data: data}, nil
+}
+
+// Zeros returns a rows x cols matrix of zeros
+func Zeros(rows, cols int) *Matrix {
+	return &Matrix{rows: rows, cols: cols, stride: cols, data: make([]float64, rows*cols)}
+}
+
+// Identity returns the n x n identity matrix
+func Identity(n int) *Matrix {
+	m := Zeros(n, n)
+	for i := 0; i < n; i++ {
+		m.data[i*m.stride+i] = 1.0
+	}
+	return m
+}
+
+// FromRows copies a nested slice (matrix) into a dense Matrix.
+// Every row must have the same length.
+func FromRows(mat [][]float64) (*Matrix, error) {
+	nrow, ncol := Shape(mat)
+	m := Zeros(nrow, ncol)
+	for i, row := range mat {
+		if len(row) != ncol {
+			return nil, fmt.Errorf(
+				"ragged matrix: row %d has length %d, want %d",
+				i, len(row), ncol)
+		}
+		copy(m.data[i*m.stride:i*m.stride+ncol], row)
+	}
+	return m, nil
+}
+
+// ToRows copies the matrix into a nested slice (matrix)
+func (m *Matrix) ToRows() [][]float64 {
+	mat := make([][]float64, m.rows)
+	for i := range mat {
+		mat[i] = m.Row(i)
+	}
+	return mat
+}
+
+// Shape returns the dimensions of the matrix
+func (m *Matrix) Shape() (nrow, ncol int) {
+	return m.rows, m.cols
+}
+
+// At returns the element at row i and column j.
+// It panics if i or j is out of range.
+func (m *Matrix) At(i, j int) float64 {
+	m.checkIndex(i, j)
+	return m.data[i*m.stride+j]
+}
+
+// Set assigns v to the element at row i and column j.
+// It panics if i or j is out of range.
+func (m *Matrix) Set(i, j int, v float64) {
+	m.checkIndex(i, j)
+	m.data[i*m.stride+j] = v
+}
+
+func (m *Matrix) checkIndex(i, j int) {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf(
+			"index (%d,%d) out of range for shape (%d,%d)",
+			i, j, m.rows, m.cols))
+	}
+}
+
+// rowView returns row i without copying
+func (m *Matrix) rowView(i int) []float64 {
+	if m.cols == 0 {
+		return nil
+	}
+	return m.data[i*m.stride : i*m.stride+m.cols]
+}
+
+// rowViews returns every row without copying
+func (m *Matrix) rowViews() [][]float64 {
+	rows := make([][]float64, m.rows)
+	for i := range rows {
+		rows[i] = m.rowView(i)
+	}
+	return rows
+}
+
+// Row returns a copy of row i as a slice.
+// It panics if i is out of range.
+func (m *Matrix) Row(i int) []float64 {
+	if i < 0 || i >= m.rows {
+		panic(fmt.Sprintf("row %d out of range for shape (%d,%d)", i, m.rows, m.cols))
+	}
+	row := make([]float64, m.cols)
+	copy(row, m.rowView(i))
+	return row
+}
+
+// Col returns a copy of column j as a slice.
+// It panics if j is out of range.
+func (m *Matrix) Col(j int) []float64 {
+	if j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("column %d out of range for shape (%d,%d)", j, m.rows, m.cols))
+	}
+	col := make([]float64, m.rows)
+	for i := range col {
+		col[i] = m.data[i*m.stride+j]
+	}
+	return col
+}
+
+// Slice returns a view of rows [r0, r1) and columns [c0, c1).
+// The view shares storage with m, so writes to one are seen by the other.
+func (m *Matrix) Slice(r0, r1, c0, c1 int) (*Matrix, error) {
+	if r0 < 0 || r1 > m.rows || r0 > r1 || c0 < 0 || c1 > m.cols || c0 > c1 {
+		return nil, fmt.Errorf(
+			"slice [%d:%d, %d:%d] out of range for shape (%d,%d)",
+			r0, r1, c0, c1, m.rows, m.cols)
+	}
+	view := &Matrix{rows: r1 - r0, cols: c1 - c0, stride: m.stride}
+	if view.rows > 0 && view.cols > 0 {
+		start := r0*m.stride + c0
+		end := (r1-1)*m.stride + c1
+		view.data = m.data[start:end]
+	}
+	return view, nil
+}
+
+// Copy returns a compact copy of the matrix that shares no storage with m
+func (m *Matrix) Copy() *Matrix {
+	c := Zeros(m.rows, m.cols)
+	for i := 0; i < m.rows; i++ {
+		copy(c.data[i*c.stride:], m.rowView(i))
+	}
+	return c
+}
+
+// T returns the transpose of the matrix
+func (m *Matrix) T() *Matrix {
+	t := Zeros(m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j, v := range m.rowView(i) {
+			t.data[j*t.stride+i] = v
+		}
+	}
+	return t
+}
+
+func (m *Matrix) sameShape(b *Matrix) error {
+	if m.rows != b.rows || m.cols != b.cols {
+		return fmt.Errorf(
+			"incompatible shapes: (%d,%d), (%d,%d)",
+			m.rows, m.cols, b.rows, b.cols)
+	}
+	return nil
+}
+
+// checkSymmetric returns an error if the matrix is not square
+// or not symmetric within a small relative tolerance
+func (m *Matrix) checkSymmetric() error {
+	if m.rows != m.cols {
+		return fmt.Errorf("matrix is not square: (%d,%d)", m.rows, m.cols)
+	}
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < i; j++ {
+			aij, aji := m.At(i, j), m.At(j, i)
+			if math.Abs(aij-aji) > 1e-10*math.Max(1.0, math.Max(math.Abs(aij), math.Abs(aji))) {
+				return fmt.Errorf("matrix is not symmetric: a[%d][%d] != a[%d][%d]", i, j, j, i)
+			}
+		}
+	}
+	return nil
+}
+
+// elementwise applies f to matching elements of m and b
+func (m *Matrix) elementwise(b *Matrix, f func(x, y float64) float64) (*Matrix, error) {
+	if err := m.sameShape(b); err != nil {
+		return nil, err
+	}
+	out := Zeros(m.rows, m.cols)
+	for i := 0; i < m.rows; i++ {
+		mrow, brow := m.rowView(i), b.rowView(i)
+		orow := out.rowView(i)
+		for j := range orow {
+			orow[j] = f(mrow[j], brow[j])
+		}
+	}
+	return out, nil
+}
+
+// Add adds two matrices elementwise
+func (m *Matrix) Add(b *Matrix) (*Matrix, error) {
+	return m.elementwise(b, func(x, y float64) float64 { return x + y })
+}
+
+// Sub subtracts two matrices elementwise
+func (m *Matrix) Sub(b *Matrix) (*Matrix, error) {
+	return m.elementwise(b, func(x, y float64) float64 { return x - y })
+}
+
+// MulElem multiplies two matrices elementwise (Hadamard product)
+func (m *Matrix) MulElem(b *Matrix) (*Matrix, error) {
+	return m.elementwise(b, func(x, y float64) float64 { return x * y })
+}
+
+// Scale multiplies each element of the matrix by a scalar
+func (m *Matrix) Scale(s float64) *Matrix {
+	out := Zeros(m.rows, m.cols)
+	for i := 0; i < m.rows; i++ {
+		orow := out.rowView(i)
+		for j, v := range m.rowView(i) {
+			orow[j] = s * v
+		}
+	}
+	return out
+}
+
+// Mul performs matrix multiplication
+func (m *Matrix) Mul(b *Matrix) (*Matrix, error) {
+	if m.cols != b.rows {
+		return nil, fmt.Errorf(
+			"incompatible shapes: (%d,%d), (%d,%d)",
+			m.rows, m.cols, b.rows, b.cols)
+	}
+	out := Zeros(m.rows, b.cols)
+	matMultKernel(out.rowViews(), m.rowViews(), b.rowViews(), DefaultWorkers())
+	return out, nil
+}
+
+// MulVec multiplies the matrix by a column vector
+func (m *Matrix) MulVec(v []float64) ([]float64, error) {
+	if m.cols != len(v) {
+		return nil, fmt.Errorf(
+			"incompatible shapes: (%d,%d), (%d,)",
+			m.rows, m.cols, len(v))
+	}
+	out := make([]float64, m.rows)
+	for i := range out {
+		out[i], _ = Dot(m.rowView(i), v)
+	}
+	return out, nil
+}
+
+// String formats the matrix one row per line
+func (m *Matrix) String() string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	for i := 0; i < m.rows; i++ {
+		if i > 0 {
+			sb.WriteString("\n ")
+		}
+		fmt.Fprint(&sb, m.rowView(i))
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
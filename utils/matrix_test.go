@@ -0,0 +1,160 @@
+package utils
+
+import "testing"
+
+func TestFromRows(t *testing.T) {
+	mat := [][]float64{
+		{1.0, 2.0, 3.0},
+		{4.0, 5.0, 6.0},
+	}
+	m, err := FromRows(mat)
+	if err != nil {
+		t.Fatalf("error calling FromRows([[1, 2, 3], [4, 5, 6]]): %s", err)
+	}
+	nrow, ncol := m.Shape()
+	if nrow != 2 || ncol != 3 {
+		t.Fatalf("FromRows([[1, 2, 3], [4, 5, 6]]).Shape() = (%d,%d); want (2,3)", nrow, ncol)
+	}
+	for i, row := range m.ToRows() {
+		if !VectorsEqual(row, mat[i]) {
+			t.Fatalf("FromRows([[1, 2, 3], [4, 5, 6]]).ToRows()[%d] = %v; want %v", i, row, mat[i])
+		}
+	}
+
+	_, err = FromRows([][]float64{{1.0, 2.0}, {3.0}})
+	if err == nil {
+		t.Fatalf("FromRows([[1, 2], [3]]) should raise error: ragged matrix")
+	}
+}
+
+func TestEmptyMatrix(t *testing.T) {
+	nrow, ncol := Shape([][]float64{})
+	if nrow != 0 || ncol != 0 {
+		t.Fatalf("Shape([]) = (%d,%d); want (0,0)", nrow, ncol)
+	}
+	m, err := FromRows(nil)
+	if err != nil {
+		t.Fatalf("error calling FromRows(nil): %s", err)
+	}
+	if len(m.ToRows()) != 0 {
+		t.Fatalf("FromRows(nil).ToRows() = %v; want []", m.ToRows())
+	}
+
+	// rows without columns and columns without rows
+	noCols := Zeros(3, 0)
+	if rows := noCols.ToRows(); len(rows) != 3 || len(rows[0]) != 0 {
+		t.Fatalf("Zeros(3, 0).ToRows() = %v; want [[] [] []]", rows)
+	}
+	if row := noCols.Row(2); len(row) != 0 {
+		t.Fatalf("Zeros(3, 0).Row(2) = %v; want []", row)
+	}
+	noRows := Zeros(0, 3)
+	if col := noRows.Col(0); len(col) != 0 {
+		t.Fatalf("Zeros(0, 3).Col(0) = %v; want []", col)
+	}
+	if cols := noRows.T().ToRows(); len(cols) != 3 || len(cols[1]) != 0 {
+		t.Fatalf("Zeros(0, 3).T().ToRows() = %v; want [[] [] []]", cols)
+	}
+}
+
+func TestNewMatrix(t *testing.T) {
+	_, err := NewMatrix(2, 3, []float64{1.0, 2.0})
+	if err == nil {
+		t.Fatalf("NewMatrix(2, 3, [1, 2]) should raise error: data length 2 does not match shape (2,3)")
+	}
+	_, err = NewMatrix(-1, 3, nil)
+	if err == nil {
+		t.Fatalf("NewMatrix(-1, 3, nil) should raise error: invalid dimensions")
+	}
+}
+
+func TestMatrixSlice(t *testing.T) {
+	m, _ := NewMatrix(3, 3, []float64{
+		1.0, 2.0, 3.0,
+		4.0, 5.0, 6.0,
+		7.0, 8.0, 9.0,
+	})
+	view, err := m.Slice(1, 3, 1, 3)
+	if err != nil {
+		t.Fatalf("error calling Slice(1, 3, 1, 3): %s", err)
+	}
+	if !VectorsEqual(view.Row(1), []float64{8.0, 9.0}) {
+		t.Fatalf("Slice(1, 3, 1, 3).Row(1) = %v; want [8, 9]", view.Row(1))
+	}
+	if !VectorsEqual(view.Col(0), []float64{5.0, 8.0}) {
+		t.Fatalf("Slice(1, 3, 1, 3).Col(0) = %v; want [5, 8]", view.Col(0))
+	}
+
+	view.Set(0, 0, 50.0)
+	if m.At(1, 1) != 50.0 {
+		t.Fatalf("writing to a view should update the parent: At(1, 1) = %f; want 50", m.At(1, 1))
+	}
+
+	if _, err := m.Slice(0, 4, 0, 1); err == nil {
+		t.Fatalf("Slice(0, 4, 0, 1) should raise error: out of range")
+	}
+}
+
+func TestMatrixArithmetic(t *testing.T) {
+	a, _ := NewMatrix(2, 2, []float64{1.0, 2.0, 3.0, 4.0})
+	b, _ := NewMatrix(2, 2, []float64{5.0, 6.0, 7.0, 8.0})
+
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatalf("error calling Add: %s", err)
+	}
+	if !VectorsEqual(sum.data, []float64{6.0, 8.0, 10.0, 12.0}) {
+		t.Fatalf("[[1, 2], [3, 4]] + [[5, 6], [7, 8]] = %v; want [[6, 8], [10, 12]]", sum)
+	}
+
+	diff, _ := b.Sub(a)
+	if !VectorsEqual(diff.data, []float64{4.0, 4.0, 4.0, 4.0}) {
+		t.Fatalf("[[5, 6], [7, 8]] - [[1, 2], [3, 4]] = %v; want [[4, 4], [4, 4]]", diff)
+	}
+
+	scaled := a.Scale(2.0)
+	if !VectorsEqual(scaled.data, []float64{2.0, 4.0, 6.0, 8.0}) {
+		t.Fatalf("2 * [[1, 2], [3, 4]] = %v; want [[2, 4], [6, 8]]", scaled)
+	}
+
+	if !VectorsEqual(a.T().data, []float64{1.0, 3.0, 2.0, 4.0}) {
+		t.Fatalf("[[1, 2], [3, 4]].T() = %v; want [[1, 3], [2, 4]]", a.T())
+	}
+
+	c, _ := NewMatrix(2, 3, nil)
+	if _, err := a.Add(c); err == nil {
+		t.Fatalf("Add with shapes (2,2), (2,3) should raise error: incompatible shapes")
+	}
+}
+
+func TestMatrixMul(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{1.0, 2.0, 3.0, 4.0, 5.0},
+		{2.0, 3.0, 4.0, 5.0, 6.0},
+	})
+	B, _ := FromRows([][]float64{
+		{3.0, 4.0},
+		{5.0, 6.0},
+		{7.0, 4.0},
+		{5.0, 6.0},
+		{7.0, 8.0},
+	})
+	actual, err := A.Mul(B)
+	if err != nil {
+		t.Fatalf("error calling Mul: %s", err)
+	}
+	if !VectorsEqual(actual.data, []float64{89.0, 92.0, 116.0, 120.0}) {
+		t.Fatalf("A.Mul(B) = %v; want [[89, 92], [116, 120]]", actual)
+	}
+	if _, err := A.Mul(A); err == nil {
+		t.Fatalf("Mul with shapes (2,5), (2,5) should raise error: incompatible shapes")
+	}
+
+	v, err := A.MulVec([]float64{1.0, 1.0, 1.0, 1.0, 1.0})
+	if err != nil {
+		t.Fatalf("error calling MulVec: %s", err)
+	}
+	if !VectorsEqual(v, []float64{15.0, 20.0}) {
+		t.Fatalf("A.MulVec([1, 1, 1, 1, 1]) = %v; want [15, 20]", v)
+	}
+}
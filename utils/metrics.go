@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+)
+
+// checkPredictions returns an error unless y and its
+// predictions yhat are non-empty and the same length
+func checkPredictions[T Float](y, yhat []T) error {
+	if len(y) != len(yhat) {
+		return fmt.Errorf("vectors are of unequal size: %d != %d", len(y), len(yhat))
+	}
+	return checkLen(y, 1)
+}
+
+// MeanAbsoluteError gives the average absolute difference
+// between values y and their predictions yhat
+func MeanAbsoluteError[T Float](y, yhat []T) (T, error) {
+	if err := checkPredictions(y, yhat); err != nil {
+		return 0.0, err
+	}
+	var sum float64
+	for i, yi := range y {
+		sum += math.Abs(float64(yi - yhat[i]))
+	}
+	return T(sum / float64(len(y))), nil
+}
+
+// MeanSquaredError gives the average squared difference
+// between values y and their predictions yhat
+func MeanSquaredError[T Float](y, yhat []T) (T, error) {
+	if err := checkPredictions(y, yhat); err != nil {
+		return 0.0, err
+	}
+	sse, err := SquaredDistance(y, yhat)
+	if err != nil {
+		return 0.0, err
+	}
+	return sse / T(len(y)), nil
+}
+
+// RootMeanSquaredError gives the square root of the mean squared
+// error, in the same units as y
+func RootMeanSquaredError[T Float](y, yhat []T) (T, error) {
+	mse, err := MeanSquaredError(y, yhat)
+	if err != nil {
+		return 0.0, err
+	}
+	return T(math.Sqrt(float64(mse))), nil
+}
+
+// MeanAbsolutePercentageError gives the average absolute difference
+// between values y and their predictions yhat relative to y, as a
+// fraction rather than a percentage.  It is undefined when y has a 0.
+func MeanAbsolutePercentageError[T Float](y, yhat []T) (T, error) {
+	if err := checkPredictions(y, yhat); err != nil {
+		return 0.0, err
+	}
+	var sum float64
+	for i, yi := range y {
+		if yi == 0.0 {
+			return 0.0, fmt.Errorf("percentage error is undefined for y[%d] = 0", i)
+		}
+		sum += math.Abs(float64((yi - yhat[i]) / yi))
+	}
+	return T(sum / float64(len(y))), nil
+}
+
+// MedianAbsoluteError gives the median absolute difference between
+// values y and their predictions yhat, which is robust to outliers
+func MedianAbsoluteError[T Float](y, yhat []T) (T, error) {
+	if err := checkPredictions(y, yhat); err != nil {
+		return 0.0, err
+	}
+	abs := make([]T, len(y))
+	for i, yi := range y {
+		abs[i] = T(math.Abs(float64(yi - yhat[i])))
+	}
+	return Median(abs)
+}
+
+// RSquared gives the coefficient of determination, the share of the
+// variance of y explained by its predictions yhat
+func RSquared[T Float](y, yhat []T) (T, error) {
+	if err := checkPredictions(y, yhat); err != nil {
+		return 0.0, err
+	}
+	sse, _ := SquaredDistance(y, yhat)
+	tss, _ := TotalSumOfSquares(y)
+	if tss == 0.0 {
+		return 0.0, fmt.Errorf("R-squared is undefined for constant y")
+	}
+	return 1.0 - sse/tss, nil
+}
+
+// AdjustedRSquared gives R-squared penalized for the number of
+// predictors p used to fit yhat, not counting the intercept
+func AdjustedRSquared[T Float](y, yhat []T, p int) (T, error) {
+	r2, err := RSquared(y, yhat)
+	if err != nil {
+		return 0.0, err
+	}
+	n := len(y)
+	if p < 0 || n-p-1 < 1 {
+		return 0.0, fmt.Errorf("need more values than predictors plus 1, got %d values and %d predictors", n, p)
+	}
+	return 1.0 - (1.0-r2)*T(n-1)/T(n-p-1), nil
+}
+
+// ExplainedVariance gives the share of the variance of y explained by
+// its predictions yhat, ignoring any bias in the predictions.  It equals
+// R-squared when the errors have mean 0.
+func ExplainedVariance[T Float](y, yhat []T) (T, error) {
+	if err := checkPredictions(y, yhat); err != nil {
+		return 0.0, err
+	}
+	residuals, _ := VectorSub(y, yhat)
+	ssr, _ := TotalSumOfSquares(residuals)
+	tss, _ := TotalSumOfSquares(y)
+	if tss == 0.0 {
+		return 0.0, fmt.Errorf("explained variance is undefined for constant y")
+	}
+	return 1.0 - ssr/tss, nil
+}
+
+// PoissonDeviance gives the mean Poisson deviance of counts y from
+// predicted rates yhat, 2 * mean(y*log(y/yhat) - (y - yhat)).
+// Counts must be non-negative and predictions positive.
+func PoissonDeviance[T Float](y, yhat []T) (T, error) {
+	if err := checkPredictions(y, yhat); err != nil {
+		return 0.0, err
+	}
+	var sum float64
+	for i, yi := range y {
+		mu := float64(yhat[i])
+		if yi < 0.0 || mu <= 0.0 {
+			return 0.0, fmt.Errorf("need y >= 0 and yhat > 0, got %v and %v at %d", yi, yhat[i], i)
+		}
+		d := -(float64(yi) - mu)
+		if yi > 0.0 {
+			d += float64(yi) * math.Log(float64(yi)/mu)
+		}
+		sum += 2.0 * d
+	}
+	return T(sum / float64(len(y))), nil
+}
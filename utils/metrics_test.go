@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func round4(x float64) float64 {
+	return math.Round(x*10000) / 10000
+}
+
+// the example from scikit-learn's regression metrics
+var (
+	metricY    = []float64{3.0, -0.5, 2.0, 7.0}
+	metricYHat = []float64{2.5, 0.0, 2.0, 8.0}
+)
+
+func TestRegressionMetrics(t *testing.T) {
+	metrics := []struct {
+		name string
+		f    func(y, yhat []float64) (float64, error)
+		want float64
+	}{
+		{"MeanAbsoluteError", MeanAbsoluteError[float64], 0.5},
+		{"MeanSquaredError", MeanSquaredError[float64], 0.375},
+		{"RootMeanSquaredError", RootMeanSquaredError[float64], 0.6124},
+		{"MeanAbsolutePercentageError", MeanAbsolutePercentageError[float64], 0.3274},
+		{"MedianAbsoluteError", MedianAbsoluteError[float64], 0.5},
+		{"RSquared", RSquared[float64], 0.9486},
+		{"ExplainedVariance", ExplainedVariance[float64], 0.9572},
+	}
+	for _, m := range metrics {
+		actual, err := m.f(metricY, metricYHat)
+		if err != nil {
+			t.Fatalf("error calling %s(y, yhat): %s", m.name, err)
+		}
+		if round4(actual) != m.want {
+			t.Fatalf("%s(y, yhat) = %f; want %f", m.name, actual, m.want)
+		}
+		if _, err := m.f(metricY, metricYHat[1:]); err == nil {
+			t.Fatalf("%s with short yhat should raise error: vectors are of unequal size", m.name)
+		}
+		if _, err := m.f([]float64{}, []float64{}); err == nil {
+			t.Fatalf("%s([], []) should raise error: need at least 1 values, got 0", m.name)
+		}
+	}
+
+	if actual, _ := AdjustedRSquared(metricY, metricYHat, 1); round4(actual) != 0.9229 {
+		t.Fatalf("AdjustedRSquared(y, yhat, 1) = %f; want 0.9229", actual)
+	}
+	if _, err := AdjustedRSquared(metricY, metricYHat, 3); err == nil {
+		t.Fatalf("AdjustedRSquared(y, yhat, 3) should raise error: need more values than predictors plus 1")
+	}
+	if _, err := MeanAbsolutePercentageError([]float64{1.0, 0.0}, []float64{1.0, 1.0}); err == nil {
+		t.Fatalf("MeanAbsolutePercentageError with y = 0 should raise error: percentage error is undefined")
+	}
+	if _, err := RSquared([]float64{2.0, 2.0}, []float64{1.0, 3.0}); err == nil {
+		t.Fatalf("RSquared with constant y should raise error: R-squared is undefined for constant y")
+	}
+}
+
+func TestPoissonDeviance(t *testing.T) {
+	actual, err := PoissonDeviance([]float64{2.0, 0.0, 1.0, 4.0}, []float64{0.5, 0.5, 2.0, 2.0})
+	if err != nil {
+		t.Fatalf("error calling PoissonDeviance(y, yhat): %s", err)
+	}
+	if round4(actual) != 1.426 {
+		t.Fatalf("PoissonDeviance(y, yhat) = %f; want 1.426", actual)
+	}
+	if _, err := PoissonDeviance([]float64{1.0}, []float64{0.0}); err == nil {
+		t.Fatalf("PoissonDeviance with yhat = 0 should raise error: need y >= 0 and yhat > 0")
+	}
+}
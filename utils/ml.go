@@ -5,16 +5,18 @@ import (
 )
 
 // TrainTestSplit splits a data set into groups
-// for training and testing a model
+// for training and testing a model, drawing from r
+// so the split can be reproduced
 func TrainTestSplit(
 	x [][]float64,
 	y []float64,
 	testP float64,
+	r *rand.Rand,
 ) ([][]float64, [][]float64, []float64, []float64) {
 	var xTrain, xTest [][]float64
 	var yTrain, yTest []float64
 	for i, row := range x {
-		if rand.Float64() < testP {
+		if r.Float64() < testP {
 			xTest = append(xTest, row)
 			yTest = append(yTest, y[i])
 		} else {
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestTrainTestSplit(t *testing.T) {
+	x := make([][]float64, 100)
+	y := make([]float64, 100)
+	for i := range x {
+		x[i] = []float64{float64(i)}
+		y[i] = float64(i)
+	}
+	xTrain, xTest, yTrain, yTest := TrainTestSplit(x, y, 0.3, rand.New(rand.NewSource(42)))
+	if len(xTrain)+len(xTest) != 100 || len(xTrain) != len(yTrain) || len(xTest) != len(yTest) {
+		t.Fatalf("TrainTestSplit sizes = %d, %d, %d, %d; want train and test to cover 100 rows",
+			len(xTrain), len(xTest), len(yTrain), len(yTest))
+	}
+	for i, row := range xTest {
+		if row[0] != yTest[i] {
+			t.Fatalf("TrainTestSplit test row %d = %v with label %f; want rows and labels kept together", i, row, yTest[i])
+		}
+	}
+
+	// the same seed gives the same split
+	_, _, yTrain2, yTest2 := TrainTestSplit(x, y, 0.3, rand.New(rand.NewSource(42)))
+	if !slices.Equal(yTrain, yTrain2) || !slices.Equal(yTest, yTest2) {
+		t.Fatalf("TrainTestSplit with seed 42 = %v, then %v; want equal", yTest, yTest2)
+	}
+}
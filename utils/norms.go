@@ -0,0 +1,242 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// FrobeniusNorm returns the square root of the sum of squared elements
+func FrobeniusNorm(m *Matrix) float64 {
+	var nrm float64
+	for i := 0; i < m.rows; i++ {
+		for _, v := range m.rowView(i) {
+			nrm = math.Hypot(nrm, v)
+		}
+	}
+	return nrm
+}
+
+// L1Norm returns the maximum absolute column sum
+func L1Norm(m *Matrix) float64 {
+	colsums := make([]float64, m.cols)
+	for i := 0; i < m.rows; i++ {
+		for j, v := range m.rowView(i) {
+			colsums[j] += math.Abs(v)
+		}
+	}
+	var nrm float64
+	for _, s := range colsums {
+		nrm = math.Max(nrm, s)
+	}
+	return nrm
+}
+
+// InfNorm returns the maximum absolute row sum
+func InfNorm(m *Matrix) float64 {
+	var nrm float64
+	for i := 0; i < m.rows; i++ {
+		var s float64
+		for _, v := range m.rowView(i) {
+			s += math.Abs(v)
+		}
+		nrm = math.Max(nrm, s)
+	}
+	return nrm
+}
+
+// SpectralNorm returns the largest singular value
+func SpectralNorm(m *Matrix) (float64, error) {
+	svd, err := NewSVD(m)
+	if err != nil {
+		return 0.0, err
+	}
+	if len(svd.values) == 0 {
+		return 0.0, nil
+	}
+	return svd.values[0], nil
+}
+
+// Cond returns the 2-norm condition number, the ratio of the largest
+// to the smallest singular value.  It is +Inf for a rank deficient matrix.
+func Cond(m *Matrix) (float64, error) {
+	svd, err := NewSVD(m)
+	if err != nil {
+		return 0.0, err
+	}
+	if len(svd.values) == 0 {
+		return 0.0, nil
+	}
+	smin := svd.values[len(svd.values)-1]
+	if smin == 0.0 {
+		return math.Inf(1), nil
+	}
+	return svd.values[0] / smin, nil
+}
+
+// Cond1 estimates the 1-norm condition number ||A||*||A^-1|| of a square
+// matrix from its LU factorization.  It is +Inf for a singular matrix.
+func Cond1(m *Matrix) (float64, error) {
+	lu, err := NewLU(m)
+	if err != nil {
+		return 0.0, err
+	}
+	if lu.Singular() {
+		return math.Inf(1), nil
+	}
+	inv, err := lu.Inverse()
+	if err != nil {
+		return 0.0, err
+	}
+	return L1Norm(m) * L1Norm(inv), nil
+}
+
+// Rank returns the numerical rank of a matrix, the number of singular
+// values above max(m, n) * eps * the largest singular value
+func Rank(m *Matrix) (int, error) {
+	svd, err := NewSVD(m)
+	if err != nil {
+		return 0, err
+	}
+	return svd.rank(), nil
+}
+
+// rank counts the singular values that are not negligible
+func (f *SVD) rank() int {
+	if len(f.values) == 0 {
+		return 0
+	}
+	m, _ := f.u.Shape()
+	n, _ := f.v.Shape()
+	var rank int
+	for _, s := range f.values {
+		if s > float64(max(m, n))*epsilon*f.values[0] {
+			rank++
+		}
+	}
+	return rank
+}
+
+// CollinearGroup is a set of design matrix columns that are
+// nearly linear combinations of each other
+type CollinearGroup struct {
+	ConditionIndex float64
+	Columns        []int
+}
+
+// CollinearityReport summarizes the conditioning of a design matrix
+type CollinearityReport struct {
+	ConditionNumber float64
+	Rank            int
+	Groups          []CollinearGroup
+}
+
+// Collinear reports whether any collinear columns were found
+func (r *CollinearityReport) Collinear() bool {
+	return len(r.Groups) > 0
+}
+
+// String gives one warning line per collinear group
+func (r *CollinearityReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "condition number: %.2f, rank: %d", r.ConditionNumber, r.Rank)
+	for _, g := range r.Groups {
+		fmt.Fprintf(&sb,
+			"\nwarning: columns %v are collinear (condition index %.2f)",
+			g.Columns, g.ConditionIndex)
+	}
+	return sb.String()
+}
+
+// DiagnoseCollinearity looks for collinear columns in a design matrix using
+// Belsley's condition indices and variance-decomposition proportions.
+// Columns are scaled to unit length first, so an intercept column is
+// treated like any other.  Singular directions with a condition index
+// above threshold (30 if threshold is 0) are reported along with the
+// columns that have more than half of their coefficient variance
+// tied up in that direction.
+func DiagnoseCollinearity(x [][]float64, threshold float64) (*CollinearityReport, error) {
+	if threshold == 0.0 {
+		threshold = 30.0
+	}
+	xMat, err := FromRows(x)
+	if err != nil {
+		return nil, err
+	}
+	nrow, ncol := xMat.Shape()
+	for j := 0; j < ncol; j++ {
+		var nrm float64
+		for i := 0; i < nrow; i++ {
+			nrm = math.Hypot(nrm, xMat.At(i, j))
+		}
+		if nrm > 0.0 {
+			for i := 0; i < nrow; i++ {
+				xMat.Set(i, j, xMat.At(i, j)/nrm)
+			}
+		}
+	}
+
+	svd, err := NewSVD(xMat)
+	if err != nil {
+		return nil, err
+	}
+	values := svd.values
+	report := &CollinearityReport{Rank: svd.rank()}
+	if len(values) == 0 {
+		return report, nil
+	}
+	smax := values[0]
+	// an all-zero design has no direction to measure against,
+	// so every column is reported as collinear
+	if smax == 0.0 {
+		report.ConditionNumber = math.Inf(1)
+		columns := make([]int, ncol)
+		for k := range columns {
+			columns[k] = k
+		}
+		report.Groups = []CollinearGroup{{ConditionIndex: math.Inf(1), Columns: columns}}
+		return report, nil
+	}
+	// clamp zero singular values so exact collinearity shows up
+	// as a very large but finite condition index
+	floor := float64(max(nrow, ncol)) * epsilon * smax
+	clamped := make([]float64, len(values))
+	for j, s := range values {
+		clamped[j] = math.Max(s, floor)
+	}
+	report.ConditionNumber = smax / clamped[len(clamped)-1]
+
+	// phi[k][j] is the share of coefficient k's variance from direction j
+	v := svd.v
+	phi := make([][]float64, ncol)
+	for k := range phi {
+		phi[k] = make([]float64, len(values))
+		for j := range values {
+			phi[k][j] = v.At(k, j) * v.At(k, j) / (clamped[j] * clamped[j])
+		}
+		total := VectorSum(phi[k])
+		for j := range phi[k] {
+			phi[k][j] /= total
+		}
+	}
+
+	for j := range values {
+		index := smax / clamped[j]
+		if index <= threshold {
+			continue
+		}
+		var columns []int
+		for k := 0; k < ncol; k++ {
+			if phi[k][j] > 0.5 {
+				columns = append(columns, k)
+			}
+		}
+		if len(columns) > 1 {
+			report.Groups = append(report.Groups, CollinearGroup{
+				ConditionIndex: index,
+				Columns:        columns,
+			})
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNorms(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{1.0, -2.0},
+		{-3.0, 4.0},
+	})
+	if actual := FrobeniusNorm(A); math.Abs(actual-math.Sqrt(30.0)) > 1e-12 {
+		t.Fatalf("FrobeniusNorm([[1, -2], [-3, 4]]) = %f; want %f", actual, math.Sqrt(30.0))
+	}
+	if actual := L1Norm(A); actual != 6.0 {
+		t.Fatalf("L1Norm([[1, -2], [-3, 4]]) = %f; want 6", actual)
+	}
+	if actual := InfNorm(A); actual != 7.0 {
+		t.Fatalf("InfNorm([[1, -2], [-3, 4]]) = %f; want 7", actual)
+	}
+
+	B, _ := FromRows([][]float64{
+		{3.0, 2.0, 2.0},
+		{2.0, 3.0, -2.0},
+	})
+	actual, err := SpectralNorm(B)
+	if err != nil {
+		t.Fatalf("error calling SpectralNorm: %s", err)
+	}
+	if math.Abs(actual-5.0) > 1e-12 {
+		t.Fatalf("SpectralNorm([[3, 2, 2], [2, 3, -2]]) = %f; want 5", actual)
+	}
+}
+
+func TestCond(t *testing.T) {
+	D, _ := FromRows([][]float64{
+		{10.0, 0.0},
+		{0.0, 0.1},
+	})
+	actual, err := Cond(D)
+	if err != nil {
+		t.Fatalf("error calling Cond: %s", err)
+	}
+	if math.Abs(actual-100.0) > 1e-9 {
+		t.Fatalf("Cond([[10, 0], [0, 0.1]]) = %f; want 100", actual)
+	}
+	actual1, _ := Cond1(D)
+	if math.Abs(actual1-100.0) > 1e-9 {
+		t.Fatalf("Cond1([[10, 0], [0, 0.1]]) = %f; want 100", actual1)
+	}
+
+	S, _ := FromRows([][]float64{
+		{1.0, 2.0},
+		{2.0, 4.0},
+	})
+	if actual, _ := Cond1(S); !math.IsInf(actual, 1) {
+		t.Fatalf("Cond1([[1, 2], [2, 4]]) = %f; want +Inf", actual)
+	}
+}
+
+func TestRank(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{1.0, 2.0, 3.0},
+		{2.0, 4.0, 6.0},
+		{1.0, 0.0, 1.0},
+		{0.0, 2.0, 2.0},
+	})
+	actual, err := Rank(A)
+	if err != nil {
+		t.Fatalf("error calling Rank: %s", err)
+	}
+	if actual != 2 {
+		t.Fatalf("Rank(A) = %d; want 2", actual)
+	}
+}
+
+func TestDiagnoseCollinearity(t *testing.T) {
+	// the last column is nearly the sum of the middle two
+	x := [][]float64{
+		{1.0, 2.0, 1.0, 3.001},
+		{1.0, 4.0, 3.0, 6.998},
+		{1.0, 1.0, 5.0, 6.002},
+		{1.0, 7.0, 2.0, 9.0},
+		{1.0, 3.0, 8.0, 10.999},
+		{1.0, 5.0, 4.0, 9.001},
+	}
+	report, err := DiagnoseCollinearity(x, 0.0)
+	if err != nil {
+		t.Fatalf("error calling DiagnoseCollinearity: %s", err)
+	}
+	if !report.Collinear() {
+		t.Fatalf("DiagnoseCollinearity(x) found no collinear columns; want [1 2 3]\n%s", report)
+	}
+	columns := report.Groups[0].Columns
+	if len(columns) != 3 || columns[0] != 1 || columns[1] != 2 || columns[2] != 3 {
+		t.Fatalf("DiagnoseCollinearity(x).Groups[0].Columns = %v; want [1 2 3]", columns)
+	}
+
+	independent := [][]float64{
+		{1.0, 0.0},
+		{1.0, 1.0},
+		{1.0, 2.0},
+	}
+	report, _ = DiagnoseCollinearity(independent, 0.0)
+	if report.Collinear() || report.Rank != 2 {
+		t.Fatalf("DiagnoseCollinearity(independent) = %s; want no warnings and rank 2", report)
+	}
+
+	zeros := [][]float64{{0.0, 0.0}, {0.0, 0.0}, {0.0, 0.0}}
+	report, err = DiagnoseCollinearity(zeros, 0.0)
+	if err != nil {
+		t.Fatalf("error calling DiagnoseCollinearity(zeros): %s", err)
+	}
+	if report.Rank != 0 || !math.IsInf(report.ConditionNumber, 1) ||
+		!report.Collinear() || len(report.Groups[0].Columns) != 2 {
+		t.Fatalf("DiagnoseCollinearity(zeros) = %s; want rank 0, condition number +Inf and columns [0 1]", report)
+	}
+}
@@ -0,0 +1,8 @@
+package utils
+
+// Float is the set of floating point types the vector, matrix
+// and stats helpers accept.  float64 callers need no type arguments,
+// while float32 halves the memory of large matrices.
+type Float interface {
+	~float32 | ~float64
+}
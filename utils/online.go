@@ -0,0 +1,378 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// OnlineMoments accumulates the count, mean, variance and range of a
+// stream of values in constant memory using Welford's update, which
+// avoids the cancellation of summing squares.  The zero value is empty
+// and ready to use, and all methods are safe to call from several
+// goroutines.  Accumulators filled separately can be merged, so a
+// stream can be split between workers and combined at the end.
+type OnlineMoments struct {
+	mu    sync.Mutex
+	state momentState
+}
+
+// momentState is a snapshot of the accumulated moments
+type momentState struct {
+	n        int
+	mean, m2 float64
+	min, max float64
+}
+
+// combine merges two snapshots with Chan et al.'s pairwise update
+func (a momentState) combine(b momentState) momentState {
+	if a.n == 0 {
+		return b
+	}
+	if b.n == 0 {
+		return a
+	}
+	n := a.n + b.n
+	delta := b.mean - a.mean
+	return momentState{
+		n:    n,
+		mean: a.mean + delta*float64(b.n)/float64(n),
+		m2:   a.m2 + b.m2 + delta*delta*float64(a.n)*float64(b.n)/float64(n),
+		min:  math.Min(a.min, b.min),
+		max:  math.Max(a.max, b.max),
+	}
+}
+
+// Add includes a value
+func (m *OnlineMoments) Add(x float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := &m.state
+	s.n++
+	if s.n == 1 {
+		s.min, s.max = x, x
+	} else {
+		s.min, s.max = math.Min(s.min, x), math.Max(s.max, x)
+	}
+	delta := x - s.mean
+	s.mean += delta / float64(s.n)
+	s.m2 += delta * (x - s.mean)
+}
+
+// snapshot returns a copy of the accumulated moments
+func (m *OnlineMoments) snapshot() momentState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state
+}
+
+// Merge includes every value added to other
+func (m *OnlineMoments) Merge(other *OnlineMoments) {
+	o := other.snapshot()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = m.state.combine(o)
+}
+
+// Count returns the number of values added
+func (m *OnlineMoments) Count() int {
+	return m.snapshot().n
+}
+
+// Mean gives the average of the values added
+func (m *OnlineMoments) Mean() (float64, error) {
+	s := m.snapshot()
+	if s.n < 1 {
+		return 0.0, fmt.Errorf("need at least 1 values, got %d", s.n)
+	}
+	return s.mean, nil
+}
+
+// Variance gives the sample variance of the values added
+func (m *OnlineMoments) Variance() (float64, error) {
+	s := m.snapshot()
+	if s.n < 2 {
+		return 0.0, fmt.Errorf("need at least 2 values, got %d", s.n)
+	}
+	return s.m2 / float64(s.n-1), nil
+}
+
+// StandardDeviation gives the sample standard deviation of the values added
+func (m *OnlineMoments) StandardDeviation() (float64, error) {
+	v, err := m.Variance()
+	if err != nil {
+		return 0.0, err
+	}
+	return math.Sqrt(v), nil
+}
+
+// Min gives the smallest value added
+func (m *OnlineMoments) Min() (float64, error) {
+	s := m.snapshot()
+	if s.n < 1 {
+		return 0.0, fmt.Errorf("need at least 1 values, got %d", s.n)
+	}
+	return s.min, nil
+}
+
+// Max gives the largest value added
+func (m *OnlineMoments) Max() (float64, error) {
+	s := m.snapshot()
+	if s.n < 1 {
+		return 0.0, fmt.Errorf("need at least 1 values, got %d", s.n)
+	}
+	return s.max, nil
+}
+
+// OnlineCovariance accumulates the covariance and correlation of a stream
+// of pairs in constant memory.  Like OnlineMoments its zero value is
+// ready to use, it is safe for concurrent use and it can be merged.
+type OnlineCovariance struct {
+	mu    sync.Mutex
+	state covState
+}
+
+// covState is a snapshot of the accumulated co-moments
+type covState struct {
+	n            int
+	meanX, meanY float64
+	m2X, m2Y     float64
+	// cXY is the sum of products of deviations from the means
+	cXY float64
+}
+
+// combine merges two snapshots with Chan et al.'s pairwise update
+func (a covState) combine(b covState) covState {
+	if a.n == 0 {
+		return b
+	}
+	if b.n == 0 {
+		return a
+	}
+	n := float64(a.n + b.n)
+	w := float64(a.n) * float64(b.n) / n
+	dx, dy := b.meanX-a.meanX, b.meanY-a.meanY
+	return covState{
+		n:     a.n + b.n,
+		meanX: a.meanX + dx*float64(b.n)/n,
+		meanY: a.meanY + dy*float64(b.n)/n,
+		m2X:   a.m2X + b.m2X + dx*dx*w,
+		m2Y:   a.m2Y + b.m2Y + dy*dy*w,
+		cXY:   a.cXY + b.cXY + dx*dy*w,
+	}
+}
+
+// Add includes a pair of values
+func (c *OnlineCovariance) Add(x, y float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := &c.state
+	s.n++
+	dx, dy := x-s.meanX, y-s.meanY
+	s.meanX += dx / float64(s.n)
+	s.meanY += dy / float64(s.n)
+	s.m2X += dx * (x - s.meanX)
+	s.m2Y += dy * (y - s.meanY)
+	// the deviation of x from the old mean times y from the new
+	s.cXY += dx * (y - s.meanY)
+}
+
+// snapshot returns a copy of the accumulated co-moments
+func (c *OnlineCovariance) snapshot() covState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
+}
+
+// Merge includes every pair added to other
+func (c *OnlineCovariance) Merge(other *OnlineCovariance) {
+	o := other.snapshot()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state = c.state.combine(o)
+}
+
+// Count returns the number of pairs added
+func (c *OnlineCovariance) Count() int {
+	return c.snapshot().n
+}
+
+// Covariance gives the sample covariance of the pairs added
+func (c *OnlineCovariance) Covariance() (float64, error) {
+	s := c.snapshot()
+	if s.n < 2 {
+		return 0.0, fmt.Errorf("need at least 2 values, got %d", s.n)
+	}
+	return s.cXY / float64(s.n-1), nil
+}
+
+// Correlation gives the linear dependence between the pairs added,
+// 0 if either value is constant
+func (c *OnlineCovariance) Correlation() (float64, error) {
+	s := c.snapshot()
+	if s.n < 2 {
+		return 0.0, fmt.Errorf("need at least 2 values, got %d", s.n)
+	}
+	if s.m2X > 0.0 && s.m2Y > 0.0 {
+		return s.cXY / math.Sqrt(s.m2X*s.m2Y), nil
+	}
+	return 0.0, nil
+}
+
+// centroid summarizes weight values with the given mean
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// TDigest estimates quantiles of a stream in bounded memory by clustering
+// values into centroids, Dunning and Ertl's merging t-digest.  Clusters
+// are kept small near the tails, so extreme quantiles stay accurate.
+// Like the other online accumulators it is safe for concurrent use and
+// digests filled separately can be merged, and the zero value is ready
+// to use with a compression of 100.
+type TDigest struct {
+	mu          sync.Mutex
+	compression float64
+	centroids   []centroid
+	// buffer holds values not yet merged into the centroids
+	buffer   []centroid
+	count    float64
+	min, max float64
+}
+
+// defaultCompression is the compression of a zero value TDigest
+const defaultCompression = 100.0
+
+// NewTDigest creates an empty t-digest.  Larger compression keeps more
+// centroids, about compression/2 once compressed, and gives more accurate
+// quantiles; 100 is a common choice.
+func NewTDigest(compression float64) (*TDigest, error) {
+	if compression < 10.0 {
+		return nil, fmt.Errorf("compression must be at least 10, got %f", compression)
+	}
+	return &TDigest{compression: compression}, nil
+}
+
+// scale maps a quantile to the scale k(q) = compression/2pi * asin(2q-1),
+// along which every merged centroid spans at most 1
+func (t *TDigest) scale(q float64) float64 {
+	return t.compression / (2.0 * math.Pi) * math.Asin(2.0*q-1.0)
+}
+
+// scaleInverse maps the scale back to a quantile
+func (t *TDigest) scaleInverse(k float64) float64 {
+	if k >= t.compression/4.0 {
+		return 1.0
+	}
+	return (math.Sin(2.0*math.Pi*k/t.compression) + 1.0) / 2.0
+}
+
+// add buffers centroids, compressing once the buffer is large.
+// The caller holds the lock.
+func (t *TDigest) add(cs []centroid, min, max float64) {
+	if t.compression == 0.0 {
+		t.compression = defaultCompression
+	}
+	for _, c := range cs {
+		t.count += c.weight
+	}
+	if len(t.centroids) == 0 && len(t.buffer) == 0 {
+		t.min, t.max = min, max
+	} else {
+		t.min, t.max = math.Min(t.min, min), math.Max(t.max, max)
+	}
+	t.buffer = append(t.buffer, cs...)
+	if len(t.buffer) > int(5.0*t.compression) {
+		t.compress()
+	}
+}
+
+// compress merges the buffer into the centroids, sweeping them in order of
+// their means.  The caller holds the lock.
+func (t *TDigest) compress() {
+	if len(t.buffer) == 0 {
+		return
+	}
+	all := append(t.centroids, t.buffer...)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+	merged := []centroid{all[0]}
+	var before float64
+	limit := t.scaleInverse(t.scale(0.0) + 1.0)
+	for _, c := range all[1:] {
+		cur := &merged[len(merged)-1]
+		if (before+cur.weight+c.weight)/t.count <= limit {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+			continue
+		}
+		before += cur.weight
+		limit = t.scaleInverse(t.scale(before/t.count) + 1.0)
+		merged = append(merged, c)
+	}
+	t.centroids = merged
+	t.buffer = nil
+}
+
+// Add includes a value
+func (t *TDigest) Add(x float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.add([]centroid{{mean: x, weight: 1.0}}, x, x)
+}
+
+// Merge includes every value added to other
+func (t *TDigest) Merge(other *TDigest) {
+	other.mu.Lock()
+	cs := append(slices.Clone(other.centroids), other.buffer...)
+	min, max := other.min, other.max
+	other.mu.Unlock()
+	if len(cs) == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.add(cs, min, max)
+}
+
+// Count returns the number of values added
+func (t *TDigest) Count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return int(t.count)
+}
+
+// Quantile estimates the value below which a proportion q of the values
+// added falls, interpolating between the means of neighbouring centroids
+// and the smallest and largest values
+func (t *TDigest) Quantile(q float64) (float64, error) {
+	if q < 0.0 || q > 1.0 || math.IsNaN(q) {
+		return 0.0, fmt.Errorf("quantile must be in [0, 1], got %f", q)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.count < 1.0 {
+		return 0.0, fmt.Errorf("need at least 1 values, got 0")
+	}
+	t.compress()
+	cs := t.centroids
+	target := q * t.count
+
+	// each centroid sits at the middle of its share of the weight
+	first := cs[0].weight / 2.0
+	if target <= first {
+		return t.min + (cs[0].mean-t.min)*target/first, nil
+	}
+	position := first
+	for i := 0; i+1 < len(cs); i++ {
+		gap := (cs[i].weight + cs[i+1].weight) / 2.0
+		if target <= position+gap {
+			return cs[i].mean + (cs[i+1].mean-cs[i].mean)*(target-position)/gap, nil
+		}
+		position += gap
+	}
+	last := cs[len(cs)-1]
+	return last.mean + (t.max-last.mean)*(target-position)/(last.weight/2.0), nil
+}
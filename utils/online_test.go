@@ -0,0 +1,213 @@
+package utils
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestOnlineMoments(t *testing.T) {
+	x := []float64{2.0, 4.0, 4.0, 4.0, 5.0, 5.0, 7.0, 9.0}
+	var m OnlineMoments
+	for _, v := range x {
+		m.Add(v)
+	}
+	mean, _ := m.Mean()
+	variance, _ := m.Variance()
+	lo, _ := m.Min()
+	hi, _ := m.Max()
+	if m.Count() != 8 || mean != 5.0 || math.Abs(variance-32.0/7.0) > 1e-12 || lo != 2.0 || hi != 9.0 {
+		t.Fatalf("OnlineMoments of %v = count %d, mean %f, variance %f, min %f, max %f; want 8, 5, 4.571429, 2, 9",
+			x, m.Count(), mean, variance, lo, hi)
+	}
+
+	// merging two halves gives the same moments as the whole
+	var a, b OnlineMoments
+	for _, v := range x[:3] {
+		a.Add(v)
+	}
+	for _, v := range x[3:] {
+		b.Add(v)
+	}
+	a.Merge(&b)
+	mergedMean, _ := a.Mean()
+	mergedVar, _ := a.Variance()
+	mergedMin, _ := a.Min()
+	if a.Count() != 8 || mergedMean != mean || math.Abs(mergedVar-variance) > 1e-12 || mergedMin != 2.0 {
+		t.Fatalf("merged OnlineMoments = count %d, mean %f, variance %f, min %f; want 8, %f, %f, 2",
+			a.Count(), mergedMean, mergedVar, mergedMin, mean, variance)
+	}
+
+	// Welford's update keeps precision when the mean is large
+	var big OnlineMoments
+	for _, v := range []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16} {
+		big.Add(v)
+	}
+	if v, _ := big.Variance(); v != 30.0 {
+		t.Fatalf("OnlineMoments variance of 1e9 + [4 7 13 16] = %f; want 30", v)
+	}
+
+	var empty OnlineMoments
+	if _, err := empty.Mean(); err == nil {
+		t.Fatalf("empty OnlineMoments.Mean() should raise error: need at least 1 values, got 0")
+	}
+	empty.Add(1.0)
+	if _, err := empty.Variance(); err == nil {
+		t.Fatalf("OnlineMoments.Variance() of 1 value should raise error: need at least 2 values, got 1")
+	}
+}
+
+func TestOnlineMomentsConcurrent(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	x := make([]float64, 10000)
+	for i := range x {
+		x[i] = r.NormFloat64()*3.0 + 10.0
+	}
+	// half the workers add to a shared accumulator,
+	// the rest fill their own and merge it in
+	var shared OnlineMoments
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			var local OnlineMoments
+			for i := w; i < len(x); i += 8 {
+				if w%2 == 0 {
+					shared.Add(x[i])
+				} else {
+					local.Add(x[i])
+				}
+			}
+			shared.Merge(&local)
+		}(w)
+	}
+	wg.Wait()
+	wantMean, _ := Mean(x)
+	wantVar, _ := Variance(x)
+	mean, _ := shared.Mean()
+	variance, _ := shared.Variance()
+	if shared.Count() != len(x) || math.Abs(mean-wantMean) > 1e-9 || math.Abs(variance-wantVar) > 1e-9 {
+		t.Fatalf("concurrent OnlineMoments = count %d, mean %f, variance %f; want %d, %f, %f",
+			shared.Count(), mean, variance, len(x), wantMean, wantVar)
+	}
+}
+
+func TestOnlineCovariance(t *testing.T) {
+	x := []float64{1.0, 2.0, 3.0, 4.0, 5.0, 6.0}
+	y := []float64{2.0, 1.0, 4.0, 3.0, 7.0, 5.0}
+	var a, b OnlineCovariance
+	for i := range x {
+		if i < 2 {
+			a.Add(x[i], y[i])
+		} else {
+			b.Add(x[i], y[i])
+		}
+	}
+	a.Merge(&b)
+	wantCov, _ := Covariance(x, y)
+	wantCorr, _ := Correlation(x, y)
+	cov, _ := a.Covariance()
+	corr, _ := a.Correlation()
+	if a.Count() != 6 || math.Abs(cov-wantCov) > 1e-12 || math.Abs(corr-wantCorr) > 1e-12 {
+		t.Fatalf("OnlineCovariance = count %d, covariance %f, correlation %f; want 6, %f, %f",
+			a.Count(), cov, corr, wantCov, wantCorr)
+	}
+	var empty OnlineCovariance
+	if _, err := empty.Covariance(); err == nil {
+		t.Fatalf("empty OnlineCovariance.Covariance() should raise error: need at least 2 values, got 0")
+	}
+}
+
+func TestTDigest(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	x := make([]float64, 100000)
+	for i := range x {
+		x[i] = r.ExpFloat64()
+	}
+	// fill two digests with halves of the data and merge them
+	a, _ := NewTDigest(100.0)
+	b, _ := NewTDigest(100.0)
+	for i, v := range x {
+		if i%2 == 0 {
+			a.Add(v)
+		} else {
+			b.Add(v)
+		}
+	}
+	a.Merge(b)
+	if a.Count() != len(x) {
+		t.Fatalf("TDigest.Count() = %d; want %d", a.Count(), len(x))
+	}
+
+	qs := []float64{0.001, 0.01, 0.25, 0.5, 0.75, 0.99, 0.999}
+	want, _ := Quantiles(x, qs, Linear)
+	minimum, _ := Quantile(x, 0.0, Linear)
+	for i, q := range qs {
+		actual, err := a.Quantile(q)
+		if err != nil {
+			t.Fatalf("error calling TDigest.Quantile(%f): %s", q, err)
+		}
+		// the share of the data below the estimate is close to q,
+		// closest in the tails where the centroids are small
+		var below int
+		for _, v := range x {
+			if v < actual {
+				below++
+			}
+		}
+		if rank := float64(below) / float64(len(x)); math.Abs(rank-q) > 0.005*math.Sqrt(q*(1.0-q))+0.0005 {
+			t.Fatalf("TDigest.Quantile(%f) = %f with rank %f; want about %f", q, actual, rank, want[i])
+		}
+	}
+	if n := len(a.centroids); n > 100 {
+		t.Fatalf("TDigest kept %d centroids; want at most 100", n)
+	}
+	if lo, _ := a.Quantile(0.0); lo != minimum {
+		t.Fatalf("TDigest.Quantile(0) = %f; want %f", lo, minimum)
+	}
+
+	// goroutines can share a digest
+	shared, _ := NewTDigest(100.0)
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(x); i += 4 {
+				shared.Add(x[i])
+			}
+		}(w)
+	}
+	wg.Wait()
+	median, _ := shared.Quantile(0.5)
+	if shared.Count() != len(x) || math.Abs(median-want[3]) > 0.01 {
+		t.Fatalf("concurrent TDigest = count %d, median %f; want %d, %f", shared.Count(), median, len(x), want[3])
+	}
+
+	small, _ := NewTDigest(100.0)
+	for _, v := range []float64{1.0, 2.0, 3.0, 4.0, 5.0} {
+		small.Add(v)
+	}
+	if m, _ := small.Quantile(0.5); m != 3.0 {
+		t.Fatalf("TDigest.Quantile(0.5) of [1 2 3 4 5] = %f; want 3", m)
+	}
+	if _, err := small.Quantile(1.5); err == nil {
+		t.Fatalf("TDigest.Quantile(1.5) should raise error: quantile must be in [0, 1]")
+	}
+	var zero TDigest
+	for i := 0; i < 10000; i++ {
+		zero.Add(float64(i))
+	}
+	if m, _ := zero.Quantile(0.5); math.Abs(m-4999.5) > 10.0 || len(zero.centroids) > 100 {
+		t.Fatalf("zero value TDigest = median %f, %d centroids; want 4999.5, at most 100", m, len(zero.centroids))
+	}
+	empty, _ := NewTDigest(100.0)
+	if _, err := empty.Quantile(0.5); err == nil {
+		t.Fatalf("empty TDigest.Quantile(0.5) should raise error: need at least 1 values, got 0")
+	}
+	if _, err := NewTDigest(1.0); err == nil {
+		t.Fatalf("NewTDigest(1) should raise error: compression must be at least 10")
+	}
+}
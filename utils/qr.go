@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+)
+
+// QR is the Householder QR factorization of an m x n matrix with m >= n,
+// such that A = Q*R where Q is m x n with orthonormal columns and
+// R is n x n upper triangular.
+type QR struct {
+	qr    *Matrix   // Householder vectors on and below the diagonal, R above it
+	rdiag []float64 // diagonal of R
+}
+
+// NewQR factorizes a matrix with at least as many rows as columns
+// using Householder reflections.  The input matrix is not modified.
+func NewQR(a *Matrix) (*QR, error) {
+	m, n := a.Shape()
+	if m < n {
+		return nil, fmt.Errorf("matrix has fewer rows than columns: (%d,%d)", m, n)
+	}
+	qr := a.Copy()
+	rdiag := make([]float64, n)
+
+	for k := 0; k < n; k++ {
+		// norm of the kth column below the diagonal
+		var nrm float64
+		for i := k; i < m; i++ {
+			nrm = math.Hypot(nrm, qr.data[i*n+k])
+		}
+		if nrm != 0.0 {
+			if qr.data[k*n+k] < 0 {
+				nrm = -nrm
+			}
+			for i := k; i < m; i++ {
+				qr.data[i*n+k] /= nrm
+			}
+			qr.data[k*n+k]++
+
+			// apply the reflection to the remaining columns
+			for j := k + 1; j < n; j++ {
+				var s float64
+				for i := k; i < m; i++ {
+					s += qr.data[i*n+k] * qr.data[i*n+j]
+				}
+				s = -s / qr.data[k*n+k]
+				for i := k; i < m; i++ {
+					qr.data[i*n+j] += s * qr.data[i*n+k]
+				}
+			}
+		}
+		rdiag[k] = -nrm
+	}
+	return &QR{qr: qr, rdiag: rdiag}, nil
+}
+
+// Rank returns the numerical rank of the factorized matrix,
+// counting diagonal elements of R that are not negligible
+func (f *QR) Rank() int {
+	m, _ := f.qr.Shape()
+	var maxDiag float64
+	for _, d := range f.rdiag {
+		maxDiag = math.Max(maxDiag, math.Abs(d))
+	}
+	tol := float64(m) * maxDiag * epsilon
+	var rank int
+	for _, d := range f.rdiag {
+		if math.Abs(d) > tol {
+			rank++
+		}
+	}
+	return rank
+}
+
+// FullRank reports whether the factorized matrix has full column rank
+func (f *QR) FullRank() bool {
+	_, n := f.qr.Shape()
+	return f.Rank() == n
+}
+
+// R returns the n x n upper triangular factor
+func (f *QR) R() *Matrix {
+	_, n := f.qr.Shape()
+	r := Zeros(n, n)
+	for i := 0; i < n; i++ {
+		r.data[i*n+i] = f.rdiag[i]
+		copy(r.data[i*n+i+1:(i+1)*n], f.qr.data[i*n+i+1:(i+1)*n])
+	}
+	return r
+}
+
+// Q returns the m x n factor with orthonormal columns
+func (f *QR) Q() *Matrix {
+	m, n := f.qr.Shape()
+	q := Zeros(m, n)
+	for k := n - 1; k >= 0; k-- {
+		q.data[k*n+k] = 1.0
+		for j := k; j < n; j++ {
+			if f.qr.data[k*n+k] == 0.0 {
+				continue
+			}
+			var s float64
+			for i := k; i < m; i++ {
+				s += f.qr.data[i*n+k] * q.data[i*n+j]
+			}
+			s = -s / f.qr.data[k*n+k]
+			for i := k; i < m; i++ {
+				q.data[i*n+j] += s * f.qr.data[i*n+k]
+			}
+		}
+	}
+	return q
+}
+
+// Solve finds the x that minimizes ||A*x - b|| in the least squares sense.
+// It returns an error if A is rank deficient.
+func (f *QR) Solve(b []float64) ([]float64, error) {
+	m, n := f.qr.Shape()
+	if len(b) != m {
+		return nil, fmt.Errorf(
+			"incompatible shapes: (%d,%d), (%d,)",
+			m, n, len(b))
+	}
+	if rank := f.Rank(); rank < n {
+		return nil, fmt.Errorf("matrix is rank deficient: rank %d < %d columns", rank, n)
+	}
+
+	// compute Q'*b
+	x := make([]float64, m)
+	copy(x, b)
+	for k := 0; k < n; k++ {
+		var s float64
+		for i := k; i < m; i++ {
+			s += f.qr.data[i*n+k] * x[i]
+		}
+		s = -s / f.qr.data[k*n+k]
+		for i := k; i < m; i++ {
+			x[i] += s * f.qr.data[i*n+k]
+		}
+	}
+	// back substitution with R
+	for k := n - 1; k >= 0; k-- {
+		x[k] /= f.rdiag[k]
+		for i := 0; i < k; i++ {
+			x[i] -= x[k] * f.qr.data[i*n+k]
+		}
+	}
+	return x[:n], nil
+}
+
+// LeastSquares finds the x that minimizes ||a*x - b|| using a QR factorization
+func LeastSquares(a *Matrix, b []float64) ([]float64, error) {
+	qr, err := NewQR(a)
+	if err != nil {
+		return nil, err
+	}
+	return qr.Solve(b)
+}
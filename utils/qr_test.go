@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQRFactors(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{12.0, -51.0, 4.0},
+		{6.0, 167.0, -68.0},
+		{-4.0, 24.0, -41.0},
+		{1.0, 2.0, 3.0},
+	})
+	qr, err := NewQR(A)
+	if err != nil {
+		t.Fatalf("error calling NewQR: %s", err)
+	}
+	prod, _ := qr.Q().Mul(qr.R())
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 3; j++ {
+			if math.Abs(prod.At(i, j)-A.At(i, j)) > 1e-9 {
+				t.Fatalf("(Q*R)[%d][%d] = %f; want %f", i, j, prod.At(i, j), A.At(i, j))
+			}
+		}
+	}
+	qtq, _ := qr.Q().T().Mul(qr.Q())
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if math.Abs(qtq.At(i, j)-Identity(3).At(i, j)) > 1e-12 {
+				t.Fatalf("Q'*Q = %v; want identity", qtq)
+			}
+		}
+	}
+
+	if _, err := NewQR(A.T()); err == nil {
+		t.Fatalf("NewQR of a (3,4) matrix should raise error: fewer rows than columns")
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	// y = 1 + 2x exactly
+	A, _ := FromRows([][]float64{
+		{1.0, 0.0},
+		{1.0, 1.0},
+		{1.0, 2.0},
+		{1.0, 3.0},
+	})
+	actual, err := LeastSquares(A, []float64{1.0, 3.0, 5.0, 7.0})
+	if err != nil {
+		t.Fatalf("error calling LeastSquares: %s", err)
+	}
+	expected := []float64{1.0, 2.0}
+	for i, xi := range actual {
+		if math.Round(xi*1e9)/1e9 != expected[i] {
+			t.Fatalf("LeastSquares(A, [1, 3, 5, 7]) = %v; want %v", actual, expected)
+		}
+	}
+}
+
+func TestRankDeficient(t *testing.T) {
+	A, _ := FromRows([][]float64{
+		{1.0, 2.0, 3.0},
+		{1.0, 4.0, 5.0},
+		{1.0, 6.0, 7.0},
+		{1.0, 8.0, 9.0},
+	})
+	qr, _ := NewQR(A)
+	if rank := qr.Rank(); rank != 2 {
+		t.Fatalf("NewQR(A).Rank() = %d; want 2", rank)
+	}
+	if _, err := qr.Solve([]float64{1.0, 2.0, 3.0, 4.0}); err == nil {
+		t.Fatalf("Solve with a rank deficient matrix should raise error: matrix is rank deficient")
+	}
+}
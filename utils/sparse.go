@@ -0,0 +1,525 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// SparseVector is a vector that only stores its nonzero elements.
+// indices are kept sorted and unique.
+type SparseVector struct {
+	n       int
+	indices []int
+	values  []float64
+}
+
+// NewSparseVector creates a vector of length n with the given nonzero
+// elements.  Indices may be unordered but must be unique and in range.
+func NewSparseVector(n int, indices []int, values []float64) (*SparseVector, error) {
+	if len(indices) != len(values) {
+		return nil, fmt.Errorf(
+			"indices and values are of unequal size: %d != %d",
+			len(indices), len(values))
+	}
+	order := make([]int, len(indices))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return indices[order[i]] < indices[order[j]] })
+
+	v := &SparseVector{n: n}
+	for k, o := range order {
+		idx := indices[o]
+		if idx < 0 || idx >= n {
+			return nil, fmt.Errorf("index %d out of range for length %d", idx, n)
+		}
+		if k > 0 && idx == indices[order[k-1]] {
+			return nil, fmt.Errorf("duplicate index %d", idx)
+		}
+		if values[o] != 0.0 {
+			v.indices = append(v.indices, idx)
+			v.values = append(v.values, values[o])
+		}
+	}
+	return v, nil
+}
+
+// SparseFromDense creates a sparse vector from the nonzero elements of x
+func SparseFromDense(x []float64) *SparseVector {
+	v := &SparseVector{n: len(x)}
+	for i, xi := range x {
+		if xi != 0.0 {
+			v.indices = append(v.indices, i)
+			v.values = append(v.values, xi)
+		}
+	}
+	return v
+}
+
+// Len returns the length of the vector, including zeros
+func (v *SparseVector) Len() int {
+	return v.n
+}
+
+// NNZ returns the number of stored nonzero elements
+func (v *SparseVector) NNZ() int {
+	return len(v.indices)
+}
+
+// At returns the element at index i
+func (v *SparseVector) At(i int) float64 {
+	k := sort.SearchInts(v.indices, i)
+	if k < len(v.indices) && v.indices[k] == i {
+		return v.values[k]
+	}
+	return 0.0
+}
+
+// Dense returns the vector as a dense slice
+func (v *SparseVector) Dense() []float64 {
+	x := make([]float64, v.n)
+	for k, i := range v.indices {
+		x[i] = v.values[k]
+	}
+	return x
+}
+
+// Dot returns the dot or inner product between two sparse vectors
+func (v *SparseVector) Dot(w *SparseVector) (float64, error) {
+	if v.n != w.n {
+		return 0.0, fmt.Errorf("vectors are of unequal size: %d != %d", v.n, w.n)
+	}
+	var dot float64
+	i, j := 0, 0
+	for i < len(v.indices) && j < len(w.indices) {
+		switch {
+		case v.indices[i] < w.indices[j]:
+			i++
+		case v.indices[i] > w.indices[j]:
+			j++
+		default:
+			dot += v.values[i] * w.values[j]
+			i++
+			j++
+		}
+	}
+	return dot, nil
+}
+
+// DotDense returns the dot or inner product with a dense vector
+func (v *SparseVector) DotDense(x []float64) (float64, error) {
+	if v.n != len(x) {
+		return 0.0, fmt.Errorf("vectors are of unequal size: %d != %d", v.n, len(x))
+	}
+	var dot float64
+	for k, i := range v.indices {
+		dot += v.values[k] * x[i]
+	}
+	return dot, nil
+}
+
+// Distance returns the euclidean distance between two sparse vectors,
+// visiting only their nonzero elements
+func (v *SparseVector) Distance(w *SparseVector) (float64, error) {
+	if v.n != w.n {
+		return 0.0, fmt.Errorf("vectors are of unequal size: %d != %d", v.n, w.n)
+	}
+	var sum float64
+	i, j := 0, 0
+	for i < len(v.indices) || j < len(w.indices) {
+		switch {
+		case j == len(w.indices) || (i < len(v.indices) && v.indices[i] < w.indices[j]):
+			sum += v.values[i] * v.values[i]
+			i++
+		case i == len(v.indices) || v.indices[i] > w.indices[j]:
+			sum += w.values[j] * w.values[j]
+			j++
+		default:
+			d := v.values[i] - w.values[j]
+			sum += d * d
+			i++
+			j++
+		}
+	}
+	return math.Sqrt(sum), nil
+}
+
+// AddScaledTo adds alpha times the vector to the dense vector x in place,
+// touching only the elements where the vector is nonzero
+func (v *SparseVector) AddScaledTo(x []float64, alpha float64) error {
+	if v.n != len(x) {
+		return fmt.Errorf("vectors are of unequal size: %d != %d", v.n, len(x))
+	}
+	for k, i := range v.indices {
+		x[i] += alpha * v.values[k]
+	}
+	return nil
+}
+
+// compress sorts (major, minor, value) triplets into compressed storage,
+// summing duplicate entries and dropping explicit zeros
+func compress(
+	nmajor, nminor int,
+	majorIdx, minorIdx []int,
+	values []float64,
+) (indptr, indices []int, vals []float64, err error) {
+	if len(majorIdx) != len(minorIdx) || len(majorIdx) != len(values) {
+		return nil, nil, nil, fmt.Errorf(
+			"triplets are of unequal size: %d, %d, %d",
+			len(majorIdx), len(minorIdx), len(values))
+	}
+	order := make([]int, len(values))
+	for k := range order {
+		if majorIdx[k] < 0 || majorIdx[k] >= nmajor || minorIdx[k] < 0 || minorIdx[k] >= nminor {
+			return nil, nil, nil, fmt.Errorf(
+				"index (%d,%d) out of range for shape (%d,%d)",
+				majorIdx[k], minorIdx[k], nmajor, nminor)
+		}
+		order[k] = k
+	}
+	sort.Slice(order, func(a, b int) bool {
+		oa, ob := order[a], order[b]
+		if majorIdx[oa] != majorIdx[ob] {
+			return majorIdx[oa] < majorIdx[ob]
+		}
+		return minorIdx[oa] < minorIdx[ob]
+	})
+
+	indptr = make([]int, nmajor+1)
+	for k := 0; k < len(order); {
+		o := order[k]
+		sum := values[o]
+		for k++; k < len(order) && majorIdx[order[k]] == majorIdx[o] && minorIdx[order[k]] == minorIdx[o]; k++ {
+			sum += values[order[k]]
+		}
+		if sum != 0.0 {
+			indices = append(indices, minorIdx[o])
+			vals = append(vals, sum)
+			indptr[majorIdx[o]+1]++
+		}
+	}
+	for i := 0; i < nmajor; i++ {
+		indptr[i+1] += indptr[i]
+	}
+	return indptr, indices, vals, nil
+}
+
+// CSR is a sparse matrix in compressed sparse row format.
+// The column indices and values of row i are stored in
+// indices[indptr[i]:indptr[i+1]] and values[indptr[i]:indptr[i+1]].
+type CSR struct {
+	rows, cols int
+	indptr     []int
+	indices    []int
+	values     []float64
+}
+
+// NewCSR creates a rows x cols sparse matrix from (row, col, value) triplets.
+// Duplicate entries are summed.
+func NewCSR(rows, cols int, rowIdx, colIdx []int, values []float64) (*CSR, error) {
+	indptr, indices, vals, err := compress(rows, cols, rowIdx, colIdx, values)
+	if err != nil {
+		return nil, err
+	}
+	return &CSR{rows: rows, cols: cols, indptr: indptr, indices: indices, values: vals}, nil
+}
+
+// CSRFromRows creates a sparse matrix from sparse row vectors,
+// which must all have the same length
+func CSRFromRows(rows []*SparseVector) (*CSR, error) {
+	m := &CSR{rows: len(rows), indptr: make([]int, len(rows)+1)}
+	if len(rows) > 0 {
+		m.cols = rows[0].n
+	}
+	for i, row := range rows {
+		if row.n != m.cols {
+			return nil, fmt.Errorf(
+				"ragged matrix: row %d has length %d, want %d",
+				i, row.n, m.cols)
+		}
+		m.indices = append(m.indices, row.indices...)
+		m.values = append(m.values, row.values...)
+		m.indptr[i+1] = len(m.indices)
+	}
+	return m, nil
+}
+
+// CSRFromDense creates a sparse matrix from the nonzero elements of a
+// nested slice (matrix)
+func CSRFromDense(mat [][]float64) (*CSR, error) {
+	rows := make([]*SparseVector, len(mat))
+	for i, row := range mat {
+		rows[i] = SparseFromDense(row)
+	}
+	return CSRFromRows(rows)
+}
+
+// Shape returns the dimensions of the matrix
+func (m *CSR) Shape() (nrow, ncol int) {
+	return m.rows, m.cols
+}
+
+// NNZ returns the number of stored nonzero elements
+func (m *CSR) NNZ() int {
+	return len(m.values)
+}
+
+// At returns the element at row i and column j
+func (m *CSR) At(i, j int) float64 {
+	return m.Row(i).At(j)
+}
+
+// Row returns row i as a sparse vector sharing storage with m
+func (m *CSR) Row(i int) *SparseVector {
+	start, end := m.indptr[i], m.indptr[i+1]
+	return &SparseVector{n: m.cols, indices: m.indices[start:end], values: m.values[start:end]}
+}
+
+// Dense returns the matrix as a dense Matrix
+func (m *CSR) Dense() *Matrix {
+	d := Zeros(m.rows, m.cols)
+	for i := 0; i < m.rows; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			d.data[i*d.stride+m.indices[k]] = m.values[k]
+		}
+	}
+	return d
+}
+
+// ToCSC converts the matrix to compressed sparse column format
+func (m *CSR) ToCSC() *CSC {
+	rowIdx := make([]int, len(m.indices))
+	for i := 0; i < m.rows; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			rowIdx[k] = i
+		}
+	}
+	// entries are already unique and in range so this cannot fail
+	csc, _ := NewCSC(m.rows, m.cols, rowIdx, m.indices, m.values)
+	return csc
+}
+
+// MulVec multiplies the matrix by a dense column vector
+func (m *CSR) MulVec(x []float64) ([]float64, error) {
+	if m.cols != len(x) {
+		return nil, fmt.Errorf(
+			"incompatible shapes: (%d,%d), (%d,)",
+			m.rows, m.cols, len(x))
+	}
+	out := make([]float64, m.rows)
+	for i := range out {
+		out[i], _ = m.Row(i).DotDense(x)
+	}
+	return out, nil
+}
+
+// Mul performs sparse-dense matrix multiplication
+func (m *CSR) Mul(b *Matrix) (*Matrix, error) {
+	nrowb, ncolb := b.Shape()
+	if m.cols != nrowb {
+		return nil, fmt.Errorf(
+			"incompatible shapes: (%d,%d), (%d,%d)",
+			m.rows, m.cols, nrowb, ncolb)
+	}
+	out := Zeros(m.rows, ncolb)
+	for i := 0; i < m.rows; i++ {
+		orow := out.rowView(i)
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			v := m.values[k]
+			for j, bkj := range b.rowView(m.indices[k]) {
+				orow[j] += v * bkj
+			}
+		}
+	}
+	return out, nil
+}
+
+// ColumnSums sums elements of the matrix along the first axis
+func (m *CSR) ColumnSums() []float64 {
+	colsums := make([]float64, m.cols)
+	for k, j := range m.indices {
+		colsums[j] += m.values[k]
+	}
+	return colsums
+}
+
+// RowSums sums elements of the matrix along the second axis
+func (m *CSR) RowSums() []float64 {
+	rowsums := make([]float64, m.rows)
+	for i := range rowsums {
+		rowsums[i] = VectorSum(m.values[m.indptr[i]:m.indptr[i+1]])
+	}
+	return rowsums
+}
+
+// NormalizeRows scales each row to have unit euclidean length.
+// Rows that are entirely zero are left unchanged.
+func (m *CSR) NormalizeRows() *CSR {
+	norm := &CSR{
+		rows:    m.rows,
+		cols:    m.cols,
+		indptr:  append([]int(nil), m.indptr...),
+		indices: append([]int(nil), m.indices...),
+		values:  make([]float64, len(m.values)),
+	}
+	for i := 0; i < m.rows; i++ {
+		row := m.values[m.indptr[i]:m.indptr[i+1]]
+		var nrm float64
+		for _, v := range row {
+			nrm = math.Hypot(nrm, v)
+		}
+		for k, v := range row {
+			if nrm > 0.0 {
+				v /= nrm
+			}
+			norm.values[m.indptr[i]+k] = v
+		}
+	}
+	return norm
+}
+
+// CSC is a sparse matrix in compressed sparse column format.
+// The row indices and values of column j are stored in
+// indices[indptr[j]:indptr[j+1]] and values[indptr[j]:indptr[j+1]].
+type CSC struct {
+	rows, cols int
+	indptr     []int
+	indices    []int
+	values     []float64
+}
+
+// NewCSC creates a rows x cols sparse matrix from (row, col, value) triplets.
+// Duplicate entries are summed.
+func NewCSC(rows, cols int, rowIdx, colIdx []int, values []float64) (*CSC, error) {
+	indptr, indices, vals, err := compress(cols, rows, colIdx, rowIdx, values)
+	if err != nil {
+		return nil, err
+	}
+	return &CSC{rows: rows, cols: cols, indptr: indptr, indices: indices, values: vals}, nil
+}
+
+// Shape returns the dimensions of the matrix
+func (m *CSC) Shape() (nrow, ncol int) {
+	return m.rows, m.cols
+}
+
+// NNZ returns the number of stored nonzero elements
+func (m *CSC) NNZ() int {
+	return len(m.values)
+}
+
+// At returns the element at row i and column j
+func (m *CSC) At(i, j int) float64 {
+	return m.Col(j).At(i)
+}
+
+// Col returns column j as a sparse vector sharing storage with m
+func (m *CSC) Col(j int) *SparseVector {
+	start, end := m.indptr[j], m.indptr[j+1]
+	return &SparseVector{n: m.rows, indices: m.indices[start:end], values: m.values[start:end]}
+}
+
+// Dense returns the matrix as a dense Matrix
+func (m *CSC) Dense() *Matrix {
+	d := Zeros(m.rows, m.cols)
+	for j := 0; j < m.cols; j++ {
+		for k := m.indptr[j]; k < m.indptr[j+1]; k++ {
+			d.data[m.indices[k]*d.stride+j] = m.values[k]
+		}
+	}
+	return d
+}
+
+// ToCSR converts the matrix to compressed sparse row format
+func (m *CSC) ToCSR() *CSR {
+	colIdx := make([]int, len(m.indices))
+	for j := 0; j < m.cols; j++ {
+		for k := m.indptr[j]; k < m.indptr[j+1]; k++ {
+			colIdx[k] = j
+		}
+	}
+	// entries are already unique and in range so this cannot fail
+	csr, _ := NewCSR(m.rows, m.cols, m.indices, colIdx, m.values)
+	return csr
+}
+
+// MulVec multiplies the matrix by a dense column vector
+func (m *CSC) MulVec(x []float64) ([]float64, error) {
+	if m.cols != len(x) {
+		return nil, fmt.Errorf(
+			"incompatible shapes: (%d,%d), (%d,)",
+			m.rows, m.cols, len(x))
+	}
+	out := make([]float64, m.rows)
+	for j, xj := range x {
+		for k := m.indptr[j]; k < m.indptr[j+1]; k++ {
+			out[m.indices[k]] += m.values[k] * xj
+		}
+	}
+	return out, nil
+}
+
+// ColumnSums sums elements of the matrix along the first axis
+func (m *CSC) ColumnSums() []float64 {
+	colsums := make([]float64, m.cols)
+	for j := range colsums {
+		colsums[j] = VectorSum(m.values[m.indptr[j]:m.indptr[j+1]])
+	}
+	return colsums
+}
+
+// Mul performs sparse-dense matrix multiplication, scattering
+// each stored element of column j into a row of the output
+func (m *CSC) Mul(b *Matrix) (*Matrix, error) {
+	nrowb, ncolb := b.Shape()
+	if m.cols != nrowb {
+		return nil, fmt.Errorf(
+			"incompatible shapes: (%d,%d), (%d,%d)",
+			m.rows, m.cols, nrowb, ncolb)
+	}
+	out := Zeros(m.rows, ncolb)
+	for j := 0; j < m.cols; j++ {
+		brow := b.rowView(j)
+		for k := m.indptr[j]; k < m.indptr[j+1]; k++ {
+			v := m.values[k]
+			orow := out.rowView(m.indices[k])
+			for c, bjc := range brow {
+				orow[c] += v * bjc
+			}
+		}
+	}
+	return out, nil
+}
+
+// RowSums sums elements of the matrix along the second axis
+func (m *CSC) RowSums() []float64 {
+	rowsums := make([]float64, m.rows)
+	for k, i := range m.indices {
+		rowsums[i] += m.values[k]
+	}
+	return rowsums
+}
+
+// NormalizeRows scales each row to have unit euclidean length.
+// Rows that are entirely zero are left unchanged.
+func (m *CSC) NormalizeRows() *CSC {
+	norms := make([]float64, m.rows)
+	for k, i := range m.indices {
+		norms[i] = math.Hypot(norms[i], m.values[k])
+	}
+	norm := &CSC{
+		rows:    m.rows,
+		cols:    m.cols,
+		indptr:  append([]int(nil), m.indptr...),
+		indices: append([]int(nil), m.indices...),
+		values:  make([]float64, len(m.values)),
+	}
+	for k, i := range m.indices {
+		norm.values[k] = m.values[k]
+		if norms[i] > 0.0 {
+			norm.values[k] /= norms[i]
+		}
+	}
+	return norm
+}
@@ -0,0 +1,187 @@
+package utils
+
+import "testing"
+
+func TestSparseVectorDot(t *testing.T) {
+	x, err := NewSparseVector(6, []int{4, 0, 2}, []float64{3.0, 1.0, 2.0})
+	if err != nil {
+		t.Fatalf("error calling NewSparseVector: %s", err)
+	}
+	if !VectorsEqual(x.Dense(), []float64{1.0, 0.0, 2.0, 0.0, 3.0, 0.0}) {
+		t.Fatalf("NewSparseVector(...).Dense() = %v; want [1, 0, 2, 0, 3, 0]", x.Dense())
+	}
+	y := SparseFromDense([]float64{2.0, 5.0, 0.0, 0.0, 4.0, 7.0})
+	actual, err := x.Dot(y)
+	if err != nil {
+		t.Fatalf("error calling Dot: %s", err)
+	}
+	if actual != 14.0 {
+		t.Fatalf("[1, 0, 2, 0, 3, 0] . [2, 5, 0, 0, 4, 7] = %f; want 14", actual)
+	}
+	actualDense, _ := x.DotDense(y.Dense())
+	if actualDense != actual {
+		t.Fatalf("DotDense = %f; want %f", actualDense, actual)
+	}
+
+	if _, err := NewSparseVector(3, []int{1, 1}, []float64{1.0, 2.0}); err == nil {
+		t.Fatalf("NewSparseVector(3, [1, 1], [1, 2]) should raise error: duplicate index")
+	}
+	if _, err := x.Dot(SparseFromDense([]float64{1.0})); err == nil {
+		t.Fatalf("Dot of vectors with lengths 6, 1 should raise error: unequal size")
+	}
+}
+
+func TestSparseVectorDistance(t *testing.T) {
+	v, _ := NewSparseVector(5, []int{0, 3}, []float64{1.0, 2.0})
+	w, _ := NewSparseVector(5, []int{1, 3}, []float64{2.0, 4.0})
+	actual, err := v.Distance(w)
+	if err != nil {
+		t.Fatalf("error calling Distance: %s", err)
+	}
+	if actual != 3.0 {
+		t.Fatalf("Distance([1 0 0 2 0], [0 2 0 4 0]) = %f; want 3", actual)
+	}
+	x := []float64{1.0, 1.0, 1.0, 1.0, 1.0}
+	if err := v.AddScaledTo(x, 2.0); err != nil || !VectorsEqual(x, []float64{3.0, 1.0, 1.0, 5.0, 1.0}) {
+		t.Fatalf("AddScaledTo([1 1 1 1 1], 2) = %v; want [3 1 1 5 1]", x)
+	}
+	short, _ := NewSparseVector(4, nil, nil)
+	if _, err := v.Distance(short); err == nil {
+		t.Fatalf("Distance with lengths 5 and 4 should raise error: vectors are of unequal size")
+	}
+}
+
+func TestCSR(t *testing.T) {
+	dense := [][]float64{
+		{1.0, 0.0, 2.0},
+		{0.0, 0.0, 0.0},
+		{0.0, 3.0, 4.0},
+	}
+	// triplets given out of order with a duplicate at (2,2)
+	m, err := NewCSR(3, 3,
+		[]int{2, 0, 2, 0, 2},
+		[]int{2, 0, 1, 2, 2},
+		[]float64{1.0, 1.0, 3.0, 2.0, 3.0})
+	if err != nil {
+		t.Fatalf("error calling NewCSR: %s", err)
+	}
+	if m.NNZ() != 4 {
+		t.Fatalf("NewCSR(...).NNZ() = %d; want 4", m.NNZ())
+	}
+	for i, row := range m.Dense().ToRows() {
+		if !VectorsEqual(row, dense[i]) {
+			t.Fatalf("NewCSR(...).Dense()[%d] = %v; want %v", i, row, dense[i])
+		}
+	}
+	if m.At(2, 1) != 3.0 || m.At(1, 1) != 0.0 {
+		t.Fatalf("At(2, 1), At(1, 1) = %f, %f; want 3, 0", m.At(2, 1), m.At(1, 1))
+	}
+	if !VectorsEqual(m.ColumnSums(), []float64{1.0, 3.0, 6.0}) {
+		t.Fatalf("ColumnSums() = %v; want [1, 3, 6]", m.ColumnSums())
+	}
+	if !VectorsEqual(m.ToCSC().ColumnSums(), []float64{1.0, 3.0, 6.0}) {
+		t.Fatalf("ToCSC().ColumnSums() = %v; want [1, 3, 6]", m.ToCSC().ColumnSums())
+	}
+
+	fromDense, _ := CSRFromDense(dense)
+	for i, row := range fromDense.ToCSC().ToCSR().Dense().ToRows() {
+		if !VectorsEqual(row, dense[i]) {
+			t.Fatalf("CSRFromDense(...).ToCSC().ToCSR()[%d] = %v; want %v", i, row, dense[i])
+		}
+	}
+
+	if _, err := NewCSR(2, 2, []int{2}, []int{0}, []float64{1.0}); err == nil {
+		t.Fatalf("NewCSR(2, 2, [2], [0], [1]) should raise error: index out of range")
+	}
+}
+
+func TestCSRMul(t *testing.T) {
+	m, _ := CSRFromDense([][]float64{
+		{1.0, 0.0, 2.0},
+		{0.0, 0.0, 0.0},
+		{0.0, 3.0, 4.0},
+	})
+	b, _ := FromRows([][]float64{
+		{1.0, 2.0},
+		{3.0, 4.0},
+		{5.0, 6.0},
+	})
+	actual, err := m.Mul(b)
+	if err != nil {
+		t.Fatalf("error calling Mul: %s", err)
+	}
+	expected, _ := m.Dense().Mul(b)
+	for i, row := range actual.ToRows() {
+		if !VectorsEqual(row, expected.Row(i)) {
+			t.Fatalf("CSR.Mul(b)[%d] = %v; want %v", i, row, expected.Row(i))
+		}
+	}
+	if _, err := m.Mul(b.T()); err == nil {
+		t.Fatalf("Mul with shapes (3,3), (2,3) should raise error: incompatible shapes")
+	}
+
+	x := []float64{1.0, 2.0, 3.0}
+	csrv, _ := m.MulVec(x)
+	cscv, _ := m.ToCSC().MulVec(x)
+	if !VectorsEqual(csrv, []float64{7.0, 0.0, 18.0}) || !VectorsEqual(cscv, csrv) {
+		t.Fatalf("MulVec([1, 2, 3]) = %v, %v; want [7, 0, 18]", csrv, cscv)
+	}
+}
+
+func TestCSRNormalizeRows(t *testing.T) {
+	m, _ := CSRFromDense([][]float64{
+		{3.0, 0.0, 4.0},
+		{0.0, 0.0, 0.0},
+	})
+	norm := m.NormalizeRows()
+	if !VectorsEqual(norm.Row(0).Dense(), []float64{0.6, 0.0, 0.8}) {
+		t.Fatalf("NormalizeRows().Row(0) = %v; want [0.6, 0, 0.8]", norm.Row(0).Dense())
+	}
+	if norm.Row(1).NNZ() != 0 {
+		t.Fatalf("NormalizeRows().Row(1) = %v; want zeros", norm.Row(1).Dense())
+	}
+	if m.At(0, 0) != 3.0 {
+		t.Fatalf("NormalizeRows should not modify the original matrix")
+	}
+}
+
+func TestCSC(t *testing.T) {
+	m, _ := CSRFromDense([][]float64{
+		{1.0, 0.0, 2.0},
+		{0.0, 0.0, 0.0},
+		{0.0, 3.0, 4.0},
+	})
+	csc := m.ToCSC()
+	b, _ := FromRows([][]float64{
+		{1.0, 2.0},
+		{3.0, 4.0},
+		{5.0, 6.0},
+	})
+	actual, err := csc.Mul(b)
+	if err != nil {
+		t.Fatalf("error calling Mul: %s", err)
+	}
+	expected, _ := m.Dense().Mul(b)
+	for i, row := range actual.ToRows() {
+		if !VectorsEqual(row, expected.Row(i)) {
+			t.Fatalf("CSC.Mul(b)[%d] = %v; want %v", i, row, expected.Row(i))
+		}
+	}
+	if _, err := csc.Mul(b.T()); err == nil {
+		t.Fatalf("Mul with shapes (3,3), (2,3) should raise error: incompatible shapes")
+	}
+	if !VectorsEqual(csc.RowSums(), []float64{3.0, 0.0, 7.0}) {
+		t.Fatalf("CSC.RowSums() = %v; want [3, 0, 7]", csc.RowSums())
+	}
+
+	norm := csc.NormalizeRows()
+	for i, row := range norm.ToCSR().Dense().ToRows() {
+		want := m.NormalizeRows().Row(i).Dense()
+		if !VectorsEqual(row, want) {
+			t.Fatalf("CSC.NormalizeRows()[%d] = %v; want %v", i, row, want)
+		}
+	}
+	if csc.At(2, 1) != 3.0 {
+		t.Fatalf("NormalizeRows should not modify the original matrix")
+	}
+}
@@ -1,53 +1,348 @@
 package utils
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
 
-// Covariance between two float64 vectors
-func Covariance(x, y []float64) float64 {
-	mux := Mean(x)
-	muy := Mean(y)
-	var cov float64
+// checkLen returns an error if x has fewer than n elements
+func checkLen[T Float](x []T, n int) error {
+	if len(x) < n {
+		return fmt.Errorf("need at least %d values, got %d", n, len(x))
+	}
+	return nil
+}
+
+// sorted returns a sorted copy of x
+func sorted[T Float](x []T) []T {
+	s := make([]T, len(x))
+	copy(s, x)
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+	return s
+}
+
+// Covariance between two float vectors
+func Covariance[T Float](x, y []T) (T, error) {
+	if len(x) != len(y) {
+		return 0.0, fmt.Errorf("vectors are of unequal size: %d != %d", len(x), len(y))
+	}
+	if err := checkLen(x, 2); err != nil {
+		return 0.0, err
+	}
+	mux, _ := Mean(x)
+	muy, _ := Mean(y)
+	var cov T
 	for i, xi := range x {
 		cov += (xi - mux) * (y[i] - muy)
 	}
-	return cov / float64(len(x)-1)
+	return cov / T(len(x)-1), nil
 }
 
 // Correlation gives the linear dependence between
-// two float64 vectors
-func Correlation(x, y []float64) float64 {
-	stdx := StandardDeviation(x)
-	stdy := StandardDeviation(y)
+// two float vectors
+func Correlation[T Float](x, y []T) (T, error) {
+	cov, err := Covariance(x, y)
+	if err != nil {
+		return 0.0, err
+	}
+	stdx, _ := StandardDeviation(x)
+	stdy, _ := StandardDeviation(y)
 	if (stdx > 0.0) && (stdy > 0.0) {
-		return Covariance(x, y) / stdx / stdy
+		return cov / stdx / stdy, nil
 	}
-	return 0.0
+	return 0.0, nil
 
 }
 
-// Mean gives the average number of a float64 vector
-func Mean(x []float64) float64 {
-	var mu float64
+// Mean gives the average number of a float vector
+func Mean[T Float](x []T) (T, error) {
+	if err := checkLen(x, 1); err != nil {
+		return 0.0, err
+	}
+	var mu T
 	for _, xi := range x {
 		mu += xi
 	}
-	return mu / float64(len(x))
+	return mu / T(len(x)), nil
 }
 
 // Variance gives how distributed a vector is around its mean
 //
 // Note: this gives the sample variance
-func Variance(x []float64) float64 {
-	mu := Mean(x)
-	var v float64
+func Variance[T Float](x []T) (T, error) {
+	if err := checkLen(x, 2); err != nil {
+		return 0.0, err
+	}
+	tss, _ := TotalSumOfSquares(x)
+	return tss / T(len(x)-1), nil
+}
+
+// TotalSumOfSquares gives the unnormalized variance,
+// the sum of squared deviations from the mean
+func TotalSumOfSquares[T Float](x []T) (T, error) {
+	mu, err := Mean(x)
+	if err != nil {
+		return 0.0, err
+	}
+	var tss T
 	for _, xi := range x {
-		v += math.Pow(xi-mu, 2)
+		tss += (xi - mu) * (xi - mu)
 	}
-	return v / float64(len(x)-1)
+	return tss, nil
 }
 
 // StandardDeviation gives the unitless dispersion of
 // a vector from its mean
-func StandardDeviation(x []float64) float64 {
-	return math.Sqrt(Variance(x))
+func StandardDeviation[T Float](x []T) (T, error) {
+	v, err := Variance(x)
+	if err != nil {
+		return 0.0, err
+	}
+	return T(math.Sqrt(float64(v))), nil
+}
+
+// QuantileMethod selects how a quantile falling between
+// two data points is interpolated
+type QuantileMethod int
+
+const (
+	// Linear interpolates between the two nearest data points
+	// (type 7 in Hyndman and Fan, the default in R and numpy)
+	Linear QuantileMethod = iota
+	// Lower takes the smaller of the two nearest data points
+	Lower
+	// Higher takes the larger of the two nearest data points
+	Higher
+	// Nearest takes the nearest data point, rounding halves to even
+	Nearest
+	// Midpoint averages the two nearest data points
+	Midpoint
+)
+
+// quantileSorted computes a quantile of already sorted data
+func quantileSorted[T Float](s []T, q float64, method QuantileMethod) (T, error) {
+	if q < 0.0 || q > 1.0 || math.IsNaN(q) {
+		return 0.0, fmt.Errorf("quantile must be in [0, 1], got %f", q)
+	}
+	h := q * float64(len(s)-1)
+	lo, hi := int(math.Floor(h)), int(math.Ceil(h))
+	switch method {
+	case Linear:
+		return s[lo] + T(h-float64(lo))*(s[hi]-s[lo]), nil
+	case Lower:
+		return s[lo], nil
+	case Higher:
+		return s[hi], nil
+	case Nearest:
+		return s[int(math.RoundToEven(h))], nil
+	case Midpoint:
+		return (s[lo] + s[hi]) / 2.0, nil
+	}
+	return 0.0, fmt.Errorf("unknown quantile method: %d", method)
+}
+
+// Quantile returns the value below which a proportion q of the data falls
+func Quantile[T Float](x []T, q float64, method QuantileMethod) (T, error) {
+	if err := checkLen(x, 1); err != nil {
+		return 0.0, err
+	}
+	return quantileSorted(sorted(x), q, method)
+}
+
+// Quantiles returns several quantiles of the data, sorting it only once
+func Quantiles[T Float](x []T, qs []float64, method QuantileMethod) ([]T, error) {
+	if err := checkLen(x, 1); err != nil {
+		return []T{}, err
+	}
+	s := sorted(x)
+	quantiles := make([]T, len(qs))
+	for i, q := range qs {
+		v, err := quantileSorted(s, q, method)
+		if err != nil {
+			return []T{}, err
+		}
+		quantiles[i] = v
+	}
+	return quantiles, nil
+}
+
+// Median gives the middle value of a vector
+func Median[T Float](x []T) (T, error) {
+	return Quantile(x, 0.5, Linear)
+}
+
+// Mode returns the most common values of a vector in increasing order
+func Mode[T Float](x []T) ([]T, error) {
+	if err := checkLen(x, 1); err != nil {
+		return []T{}, err
+	}
+	s := sorted(x)
+	var modes []T
+	best := 0
+	for i := 0; i < len(s); {
+		j := i
+		for j < len(s) && s[j] == s[i] {
+			j++
+		}
+		if count := j - i; count > best {
+			best = count
+			modes = []T{s[i]}
+		} else if count == best {
+			modes = append(modes, s[i])
+		}
+		i = j
+	}
+	return modes, nil
+}
+
+// Range gives the difference between the largest and smallest values
+func Range[T Float](x []T) (T, error) {
+	if err := checkLen(x, 1); err != nil {
+		return 0.0, err
+	}
+	lo, hi := x[0], x[0]
+	for _, xi := range x[1:] {
+		if xi < lo {
+			lo = xi
+		}
+		if xi > hi {
+			hi = xi
+		}
+	}
+	return hi - lo, nil
+}
+
+// IQR gives the interquartile range, the difference between
+// the 75th and 25th percentiles
+func IQR[T Float](x []T) (T, error) {
+	q, err := Quantiles(x, []float64{0.25, 0.75}, Linear)
+	if err != nil {
+		return 0.0, err
+	}
+	return q[1] - q[0], nil
+}
+
+// centralMoments returns the second, third and fourth
+// central moments of x, normalized by n
+func centralMoments[T Float](x []T) (m2, m3, m4 float64) {
+	mu, _ := Mean(x)
+	for _, xi := range x {
+		d := float64(xi - mu)
+		m2 += d * d
+		m3 += d * d * d
+		m4 += d * d * d * d
+	}
+	n := float64(len(x))
+	return m2 / n, m3 / n, m4 / n
+}
+
+// Skewness gives the asymmetry of a vector around its mean
+//
+// Note: this gives the adjusted Fisher-Pearson sample skewness
+func Skewness[T Float](x []T) (T, error) {
+	if err := checkLen(x, 3); err != nil {
+		return 0.0, err
+	}
+	m2, m3, _ := centralMoments(x)
+	if m2 == 0.0 {
+		return 0.0, nil
+	}
+	n := float64(len(x))
+	g1 := m3 / math.Pow(m2, 1.5)
+	return T(g1 * math.Sqrt(n*(n-1)) / (n - 2)), nil
+}
+
+// Kurtosis gives how heavy the tails of a vector are
+// relative to a normal distribution
+//
+// Note: this gives the adjusted sample excess kurtosis, which is 0
+// for normally distributed data
+func Kurtosis[T Float](x []T) (T, error) {
+	if err := checkLen(x, 4); err != nil {
+		return 0.0, err
+	}
+	m2, _, m4 := centralMoments(x)
+	if m2 == 0.0 {
+		return 0.0, nil
+	}
+	n := float64(len(x))
+	g2 := m4/(m2*m2) - 3.0
+	return T((n - 1) / ((n - 2) * (n - 3)) * ((n+1)*g2 + 6.0)), nil
+}
+
+// MAD gives the median absolute deviation from the median
+//
+// Note: the result is not scaled to estimate a normal standard deviation
+func MAD[T Float](x []T) (T, error) {
+	med, err := Median(x)
+	if err != nil {
+		return 0.0, err
+	}
+	deviations := make([]T, len(x))
+	for i, xi := range x {
+		deviations[i] = T(math.Abs(float64(xi - med)))
+	}
+	return Median(deviations)
+}
+
+// TrimmedMean gives the mean after dropping a proportion
+// of the smallest and of the largest values
+func TrimmedMean[T Float](x []T, proportion float64) (T, error) {
+	if proportion < 0.0 || proportion >= 0.5 {
+		return 0.0, fmt.Errorf("trim proportion must be in [0, 0.5), got %f", proportion)
+	}
+	if err := checkLen(x, 1); err != nil {
+		return 0.0, err
+	}
+	k := int(proportion * float64(len(x)))
+	return Mean(sorted(x)[k : len(x)-k])
+}
+
+// Description summarizes a vector like pandas' describe
+type Description[T Float] struct {
+	Count  int
+	Mean   T
+	Std    T
+	Min    T
+	Q25    T
+	Median T
+	Q75    T
+	Max    T
+}
+
+// Describe computes summary statistics of a vector
+func Describe[T Float](x []T) (Description[T], error) {
+	if err := checkLen(x, 2); err != nil {
+		return Description[T]{}, err
+	}
+	s := sorted(x)
+	mean, _ := Mean(x)
+	std, _ := StandardDeviation(x)
+	q, _ := Quantiles(s, []float64{0.25, 0.5, 0.75}, Linear)
+	return Description[T]{
+		Count:  len(x),
+		Mean:   mean,
+		Std:    std,
+		Min:    s[0],
+		Q25:    q[0],
+		Median: q[1],
+		Q75:    q[2],
+		Max:    s[len(s)-1],
+	}, nil
+}
+
+// String formats the description one statistic per line
+func (d Description[T]) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "count  %12d\n", d.Count)
+	fmt.Fprintf(&sb, "mean   %12.6f\n", d.Mean)
+	fmt.Fprintf(&sb, "std    %12.6f\n", d.Std)
+	fmt.Fprintf(&sb, "min    %12.6f\n", d.Min)
+	fmt.Fprintf(&sb, "25%%    %12.6f\n", d.Q25)
+	fmt.Fprintf(&sb, "50%%    %12.6f\n", d.Median)
+	fmt.Fprintf(&sb, "75%%    %12.6f\n", d.Q75)
+	fmt.Fprintf(&sb, "max    %12.6f", d.Max)
+	return sb.String()
 }
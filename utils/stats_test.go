@@ -6,21 +6,49 @@ import (
 )
 
 func TestMean(t *testing.T) {
-	actual := Mean([]float64{1.0, 2.0, 3.0, 4.0, 5.0})
+	actual, err := Mean([]float64{1.0, 2.0, 3.0, 4.0, 5.0})
+	if err != nil {
+		t.Fatalf("error calling Mean([1, 2, 3, 4, 5]): %s", err)
+	}
 	if actual != 3.0 {
 		t.Fatalf("Mean([1, 2, 3, 4, 5]) = %f; want 3.0", actual)
 	}
+	if _, err := Mean([]float64{}); err == nil {
+		t.Fatalf("Mean([]) should raise error: need at least 1 values, got 0")
+	}
 }
 
 func TestVariance(t *testing.T) {
-	actual := Variance([]float64{1.0, 2.0, 3.0, 4.0, 5.0})
+	actual, err := Variance([]float64{1.0, 2.0, 3.0, 4.0, 5.0})
+	if err != nil {
+		t.Fatalf("error calling Variance([1, 2, 3, 4, 5]): %s", err)
+	}
 	if actual != 2.5 {
 		t.Fatalf("Variance([1, 2, 3, 4, 5]) = %f; want 2.5", actual)
 	}
+	if _, err := Variance([]float64{1.0}); err == nil {
+		t.Fatalf("Variance([1]) should raise error: need at least 2 values, got 1")
+	}
+}
+
+func TestTotalSumOfSquares(t *testing.T) {
+	actual, err := TotalSumOfSquares([]float64{1.0, 2.0, 3.0, 4.0, 5.0})
+	if err != nil {
+		t.Fatalf("error calling TotalSumOfSquares([1, 2, 3, 4, 5]): %s", err)
+	}
+	if actual != 10.0 {
+		t.Fatalf("TotalSumOfSquares([1, 2, 3, 4, 5]) = %f; want 10.0", actual)
+	}
+	if _, err := TotalSumOfSquares([]float64{}); err == nil {
+		t.Fatalf("TotalSumOfSquares([]) should raise error: need at least 1 values, got 0")
+	}
 }
 
 func TestStandardDeviation(t *testing.T) {
-	actual := StandardDeviation([]float64{1.0, 2.0, 3.0, 4.0, 5.0})
+	actual, err := StandardDeviation([]float64{1.0, 2.0, 3.0, 4.0, 5.0})
+	if err != nil {
+		t.Fatalf("error calling StandardDeviation([1, 2, 3, 4, 5]): %s", err)
+	}
 	roundedActual := math.Round(actual*1000) / 1000
 	if roundedActual != 1.581 {
 		t.Fatalf("Variance([1, 2, 3, 4, 5]) = %f; want 1.581", roundedActual)
@@ -30,7 +58,10 @@ func TestStandardDeviation(t *testing.T) {
 func TestCovariance(t *testing.T) {
 	x := []float64{1.0, 2.0, 3.0, 4.0, 5.0}
 	y := []float64{1.0, 2.1, 3.5, 4.4, 5.3}
-	actual := Covariance(x, y)
+	actual, err := Covariance(x, y)
+	if err != nil {
+		t.Fatalf("error calling Covariance: %s", err)
+	}
 	roundedActual := math.Round(actual*1000) / 1000
 	if roundedActual != 2.725 {
 		t.Fatalf(
@@ -38,12 +69,18 @@ func TestCovariance(t *testing.T) {
 			roundedActual,
 		)
 	}
+	if _, err := Covariance(x, y[1:]); err == nil {
+		t.Fatalf("Covariance with lengths 5, 4 should raise error: unequal size")
+	}
 }
 
 func TestCorrelation(t *testing.T) {
 	x := []float64{1.0, 2.0, 3.0, 4.0, 5.0}
 	y := []float64{1.0, 2.1, 3.5, 4.4, 5.3}
-	actual := Correlation(x, y)
+	actual, err := Correlation(x, y)
+	if err != nil {
+		t.Fatalf("error calling Correlation: %s", err)
+	}
 	roundedActual := math.Round(actual*1000) / 1000
 	if roundedActual != 0.996 {
 		t.Fatalf(
@@ -52,3 +89,131 @@ func TestCorrelation(t *testing.T) {
 		)
 	}
 }
+
+func TestStatsFloat32(t *testing.T) {
+	x := []float32{1.0, 2.0, 3.0, 4.0, 5.0}
+	if actual, _ := Mean(x); actual != 3.0 {
+		t.Fatalf("Mean[float32]([1, 2, 3, 4, 5]) = %f; want 3.0", actual)
+	}
+	if actual, _ := Variance(x); actual != 2.5 {
+		t.Fatalf("Variance[float32]([1, 2, 3, 4, 5]) = %f; want 2.5", actual)
+	}
+	actual, _ := StandardDeviation(x)
+	roundedActual := math.Round(float64(actual)*1000) / 1000
+	if roundedActual != 1.581 {
+		t.Fatalf("StandardDeviation[float32]([1, 2, 3, 4, 5]) = %f; want 1.581", roundedActual)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	odd, err := Median([]float64{5.0, 1.0, 3.0})
+	if err != nil {
+		t.Fatalf("error calling Median([5, 1, 3]): %s", err)
+	}
+	if odd != 3.0 {
+		t.Fatalf("Median([5, 1, 3]) = %f; want 3", odd)
+	}
+	even, _ := Median([]float64{4.0, 1.0, 3.0, 2.0})
+	if even != 2.5 {
+		t.Fatalf("Median([4, 1, 3, 2]) = %f; want 2.5", even)
+	}
+	single, _ := Median([]float64{7.0})
+	if single != 7.0 {
+		t.Fatalf("Median([7]) = %f; want 7", single)
+	}
+	if _, err := Median([]float64{}); err == nil {
+		t.Fatalf("Median([]) should raise error: need at least 1 values, got 0")
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	x := []float64{1.0, 2.0, 3.0, 4.0}
+	// q = 0.4 falls at position 1.2 between 2 and 3
+	expected := map[QuantileMethod]float64{
+		Linear:   2.2,
+		Lower:    2.0,
+		Higher:   3.0,
+		Nearest:  2.0,
+		Midpoint: 2.5,
+	}
+	for method, want := range expected {
+		actual, err := Quantile(x, 0.4, method)
+		if err != nil {
+			t.Fatalf("error calling Quantile([1, 2, 3, 4], 0.4, %d): %s", method, err)
+		}
+		if math.Abs(actual-want) > 1e-12 {
+			t.Fatalf("Quantile([1, 2, 3, 4], 0.4, %d) = %f; want %f", method, actual, want)
+		}
+	}
+	if _, err := Quantile(x, 1.5, Linear); err == nil {
+		t.Fatalf("Quantile([1, 2, 3, 4], 1.5) should raise error: quantile must be in [0, 1]")
+	}
+}
+
+func TestMode(t *testing.T) {
+	actual, err := Mode([]float64{1.0, 2.0, 2.0, 3.0, 3.0, 4.0})
+	if err != nil {
+		t.Fatalf("error calling Mode: %s", err)
+	}
+	if !VectorsEqual(actual, []float64{2.0, 3.0}) {
+		t.Fatalf("Mode([1, 2, 2, 3, 3, 4]) = %v; want [2, 3]", actual)
+	}
+}
+
+func TestDispersion(t *testing.T) {
+	x := []float64{1.0, 2.0, 3.0, 4.0, 5.0, 6.0, 7.0, 8.0, 9.0, 100.0}
+	if actual, _ := Range(x); actual != 99.0 {
+		t.Fatalf("Range(x) = %f; want 99", actual)
+	}
+	if actual, _ := IQR(x); actual != 4.5 {
+		t.Fatalf("IQR(x) = %f; want 4.5", actual)
+	}
+	if actual, _ := MAD(x); actual != 2.5 {
+		t.Fatalf("MAD(x) = %f; want 2.5", actual)
+	}
+	actual, err := TrimmedMean(x, 0.1)
+	if err != nil {
+		t.Fatalf("error calling TrimmedMean: %s", err)
+	}
+	if actual != 5.5 {
+		t.Fatalf("TrimmedMean(x, 0.1) = %f; want 5.5", actual)
+	}
+	if _, err := TrimmedMean(x, 0.5); err == nil {
+		t.Fatalf("TrimmedMean(x, 0.5) should raise error: trim proportion must be in [0, 0.5)")
+	}
+}
+
+func TestShape(t *testing.T) {
+	x := []float64{2.0, 8.0, 0.0, 4.0, 1.0, 9.0, 9.0, 0.0}
+	skew, err := Skewness(x)
+	if err != nil {
+		t.Fatalf("error calling Skewness: %s", err)
+	}
+	if roundedSkew := math.Round(skew*1000) / 1000; roundedSkew != 0.331 {
+		t.Fatalf("Skewness(x) = %f; want 0.331", roundedSkew)
+	}
+	kurt, err := Kurtosis(x)
+	if err != nil {
+		t.Fatalf("error calling Kurtosis: %s", err)
+	}
+	if roundedKurt := math.Round(kurt*1000) / 1000; roundedKurt != -2.099 {
+		t.Fatalf("Kurtosis(x) = %f; want -2.099", roundedKurt)
+	}
+	if _, err := Kurtosis(x[:3]); err == nil {
+		t.Fatalf("Kurtosis of 3 values should raise error: need at least 4 values, got 3")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	d, err := Describe([]float64{1.0, 2.0, 3.0, 4.0, 5.0})
+	if err != nil {
+		t.Fatalf("error calling Describe: %s", err)
+	}
+	if d.Count != 5 || d.Mean != 3.0 || d.Min != 1.0 || d.Q25 != 2.0 ||
+		d.Median != 3.0 || d.Q75 != 4.0 || d.Max != 5.0 {
+		t.Fatalf("Describe([1, 2, 3, 4, 5]) =\n%s", d)
+	}
+	if _, err := Describe([]float64{1.0}); err == nil {
+		t.Fatalf("Describe([1]) should raise error: need at least 2 values, got 1")
+	}
+}